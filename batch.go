@@ -0,0 +1,148 @@
+package prose
+
+import (
+	"runtime"
+	"sync"
+)
+
+// A BatchResult holds the outcome of processing one text in a batch.
+type BatchResult struct {
+	Index    int       // The text's position within the input.
+	Document *Document // The resulting Document, which may be partial if Err != nil.
+	Err      error     // The error returned by NewDocument, if any.
+}
+
+// NewDocuments processes `texts` concurrently, using `workers` goroutines,
+// and returns the resulting Documents in input order.
+//
+// All of the Documents share a single, read-only Model: either the one given
+// by UsingModel or the default model, which is only loaded once for the
+// whole batch. If `workers` is less than 1, runtime.NumCPU() is used.
+//
+// If any text fails, NewDocuments returns the first error (by input order)
+// along with every Document it was able to create.
+func NewDocuments(texts []string, workers int, opts ...DocOpt) ([]*Document, error) {
+	docs := make([]*Document, len(texts))
+	errs := make([]error, len(texts))
+
+	opts, err := sharedModel(opts)
+	if err != nil {
+		return docs, err
+	}
+
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for i := 0; i < batchWorkers(workers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				docs[idx], errs[idx] = NewDocument(texts[idx], opts...)
+			}
+		}()
+	}
+	for idx := range texts {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return docs, err
+		}
+	}
+	return docs, nil
+}
+
+// NewDocumentsFromChan is NewDocuments for a channel of texts.
+//
+// It returns a channel that delivers one BatchResult per text, in input
+// order, and that's closed once `texts` has been closed and fully processed.
+// At most a few times `workers` texts are held in memory at once.
+func NewDocumentsFromChan(texts <-chan string, workers int, opts ...DocOpt) <-chan BatchResult {
+	workers = batchWorkers(workers)
+
+	results := make(chan BatchResult, workers)
+	opts, err := sharedModel(opts)
+
+	// Each text gets its own single-use channel, which we queue (in input
+	// order) so that results can be delivered in order even though they're
+	// computed out of order.
+	type job struct {
+		index  int
+		text   string
+		result chan BatchResult
+	}
+	jobs := make(chan job)
+	queue := make(chan chan BatchResult, 2*workers)
+
+	go func() {
+		idx := 0
+		for text := range texts {
+			result := make(chan BatchResult, 1)
+			queue <- result
+			jobs <- job{index: idx, text: text, result: result}
+			idx++
+		}
+		close(jobs)
+		close(queue)
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				if err != nil {
+					j.result <- BatchResult{Index: j.index, Err: err}
+					continue
+				}
+				doc, docErr := NewDocument(j.text, opts...)
+				j.result <- BatchResult{Index: j.index, Document: doc, Err: docErr}
+			}
+		}()
+	}
+
+	go func() {
+		for result := range queue {
+			results <- <-result
+		}
+		close(results)
+	}()
+
+	return results
+}
+
+// sharedModel resolves the Model that `opts` will use, loading the default
+// model if necessary, and returns options that pin every Document to it.
+func sharedModel(opts []DocOpt) ([]DocOpt, error) {
+	doc := Document{}
+	base := defaultOpts
+	for _, applyOpt := range opts {
+		applyOpt(&doc, &base)
+	}
+
+	if doc.Model != nil {
+		return opts, nil
+	}
+
+	pipeline, err := base.pipeline()
+	if err != nil {
+		return opts, &StageError{Stage: "pipeline", Err: err}
+	}
+
+	model, err := defaultModel(requirements(pipeline))
+	if err != nil {
+		return opts, &StageError{Stage: "model", Err: err}
+	}
+
+	shared := make([]DocOpt, 0, len(opts)+1)
+	shared = append(shared, opts...)
+	return append(shared, UsingModel(model)), nil
+}
+
+func batchWorkers(workers int) int {
+	if workers < 1 {
+		return runtime.NumCPU()
+	}
+	return workers
+}
@@ -0,0 +1,53 @@
+package prose
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewDocuments(t *testing.T) {
+	texts := getWordBenchData()[:40]
+
+	model, err := defaultModel(true, true, false)
+	if err != nil {
+		panic(err)
+	}
+
+	docs, err := NewDocuments(texts, 8, UsingModel(model))
+	if err != nil {
+		t.Fatalf("NewDocuments() unexpected error: %v", err)
+	}
+
+	stream := make(chan string)
+	go func() {
+		for _, text := range texts {
+			stream <- text
+		}
+		close(stream)
+	}()
+	results := NewDocumentsFromChan(stream, 8, UsingModel(model))
+
+	for i, text := range texts {
+		expected, err := NewDocument(text, UsingModel(model))
+		if err != nil {
+			panic(err)
+		}
+
+		if docs[i].Text != text || !reflect.DeepEqual(docs[i].Tokens(), expected.Tokens()) {
+			t.Errorf("NewDocuments() unexpected document at %v: %v", i, docs[i].Text)
+		}
+
+		result := <-results
+		if result.Err != nil || result.Index != i || result.Document.Text != text {
+			t.Fatalf("NewDocumentsFromChan() unexpected result at %v: %v", i, result)
+		}
+		if !reflect.DeepEqual(result.Document.Entities(), expected.Entities()) {
+			t.Errorf("NewDocumentsFromChan() unexpected entities at %v: %v", i,
+				result.Document.Entities())
+		}
+	}
+
+	if _, ok := <-results; ok {
+		t.Errorf("NewDocumentsFromChan() expected the results to be closed")
+	}
+}
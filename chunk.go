@@ -0,0 +1,127 @@
+package prose
+
+import "strings"
+
+// A Chunk represents a labeled, non-overlapping phrase (e.g., a noun phrase)
+// within a Document.
+type Chunk struct {
+	Span
+
+	Text  string // The chunk's text.
+	Label string // The chunk's label (e.g., "NP").
+	Head  Token  // The chunk's head (e.g., the main noun of a noun phrase).
+
+	tokens []*Token
+}
+
+// Tokens returns the tokens that make up `c`.
+func (c Chunk) Tokens() []Token {
+	tokens := make([]Token, 0, len(c.tokens))
+	for _, tok := range c.tokens {
+		tokens = append(tokens, *tok)
+	}
+	return tokens
+}
+
+// NounChunks returns `doc`'s base noun phrases: non-recursive phrases
+// consisting of a noun and its determiners and pre-modifiers (e.g., "the
+// lazy dog"), as well as personal pronouns.
+//
+// Chunking relies on POS tags, so it requires that tagging be enabled. Noun
+// phrases never cross sentence boundaries.
+func (doc *Document) NounChunks() []Chunk {
+	var chunks []Chunk
+	for _, group := range doc.tokenGroups() {
+		chunks = append(chunks, nounChunks(doc.Text, group)...)
+	}
+	return chunks
+}
+
+// nounChunks finds the base noun phrases in a single sentence.
+//
+// A noun phrase is an optional predeterminer and determiner, followed by any
+// number of modifiers, followed by one or more nouns:
+//
+//	<PDT>? <DT|PRP$|WP$>? <JJ|JJR|JJS|CD|VBN|VBG>* <NN|NNS|NNP|NNPS>+
+//
+// or a lone personal or wh-pronoun.
+func nounChunks(text string, tokens []*Token) []Chunk {
+	var chunks []Chunk
+
+	for i := 0; i < len(tokens); {
+		j := i
+		if j < len(tokens) && tokens[j].Tag == "PDT" {
+			j++
+		}
+		if j < len(tokens) && isDeterminer(tokens[j].Tag) {
+			j++
+		}
+		for j < len(tokens) && isNounModifier(tokens[j].Tag) {
+			j++
+		}
+
+		nouns := j
+		for j < len(tokens) && isNoun(tokens[j].Tag) {
+			j++
+		}
+
+		if j > nouns {
+			chunks = append(chunks, makeChunk(text, tokens[i:j], "NP", j-i-1))
+			i = j
+		} else if tag := tokens[i].Tag; tag == "PRP" || tag == "WP" {
+			chunks = append(chunks, makeChunk(text, tokens[i:i+1], "NP", 0))
+			i++
+		} else {
+			i++
+		}
+	}
+
+	return chunks
+}
+
+// makeChunk creates a Chunk from the given tokens, whose head is the token at
+// index `head`.
+func makeChunk(text string, tokens []*Token, label string, head int) Chunk {
+	first, last := tokens[0], tokens[len(tokens)-1]
+
+	chunk := Chunk{
+		Span: Span{
+			Start:     first.Start,
+			End:       last.End,
+			RuneStart: first.RuneStart,
+			RuneEnd:   last.RuneEnd},
+		Label:  label,
+		Head:   *tokens[head],
+		tokens: tokens,
+	}
+
+	if first.Start < last.End && last.End <= len(text) {
+		chunk.Text = text[first.Start:last.End]
+	} else {
+		// The tokens don't have offsets (e.g., a custom Tokenizer without a
+		// Document), so we fall back to their text.
+		words := make([]string, len(tokens))
+		for i, tok := range tokens {
+			words[i] = tok.Text
+		}
+		chunk.Text = strings.Join(words, " ")
+	}
+
+	return chunk
+}
+
+func isDeterminer(tag string) bool {
+	return tag == "DT" || tag == "PRP$" || tag == "WP$"
+}
+
+func isNounModifier(tag string) bool {
+	switch tag {
+	case "JJ", "JJR", "JJS", "CD", "VBN", "VBG":
+		return true
+	}
+	return false
+}
+
+func isNoun(tag string) bool {
+	return strings.HasPrefix(tag, "NN")
+}
@@ -0,0 +1,56 @@
+package prose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// makeTagged creates a Document from pre-tagged text, in the format accepted
+// by ReadTagged, without running the POS tagger.
+func makeTagged(tagged string) *Document {
+	words, tags := []string{}, []string{}
+	for _, tuple := range ReadTagged(tagged, "|") {
+		words = append(words, tuple[0]...)
+		tags = append(tags, tuple[1]...)
+	}
+
+	doc, err := NewDocument(strings.Join(words, " "),
+		WithTagging(false),
+		WithExtraction(false))
+	checkError(err)
+
+	for i, tok := range doc.MutableTokens() {
+		tok.Tag = tags[i]
+	}
+	return doc
+}
+
+func TestNounChunks(t *testing.T) {
+	doc := makeTagged(
+		"The|DT quick|JJ brown|JJ fox|NN jumps|VBZ over|IN the|DT lazy|JJ " +
+			"dog|NN .|. He|PRP bought|VBD all|PDT the|DT 3|CD red|JJ " +
+			"apples|NNS from|IN Whole|NNP Foods|NNPS .|.")
+
+	expected := []string{
+		"The quick brown fox", "the lazy dog", "He", "all the 3 red apples",
+		"Whole Foods"}
+	heads := []string{"fox", "dog", "He", "apples", "Foods"}
+
+	chunks := doc.NounChunks()
+	observed, observedHeads := []string{}, []string{}
+	for _, chunk := range chunks {
+		observed = append(observed, chunk.Text)
+		observedHeads = append(observedHeads, chunk.Head.Text)
+		if doc.Text[chunk.Start:chunk.End] != chunk.Text || chunk.Label != "NP" {
+			t.Errorf("NounChunks() unexpected chunk: %v", chunk)
+		}
+	}
+
+	if !reflect.DeepEqual(observed, expected) {
+		t.Errorf("NounChunks() expected = %v, got = %v", expected, observed)
+	}
+	if !reflect.DeepEqual(observedHeads, heads) {
+		t.Errorf("NounChunks() expected heads = %v, got = %v", heads, observedHeads)
+	}
+}
@@ -0,0 +1,233 @@
+package prose
+
+import (
+	"errors"
+	"io/fs"
+	"math"
+	"sort"
+	"strings"
+)
+
+// A Classification is the result of categorizing a text (see
+// WithClassification).
+type Classification struct {
+	Label string             // The most probable label.
+	Probs map[string]float64 // The probability of each label.
+}
+
+// LabeledText represents an externally-labeled text, such as a document or a
+// sentence.
+type LabeledText struct {
+	Text  string // The text itself.
+	Label string // The text's category (e.g., "spam").
+}
+
+// UsingTexts creates a text classifier from labeled data.
+func UsingTexts(data []LabeledText) DataSource {
+	return UsingTextsAndTokenizer(data, NewIterTokenizer())
+}
+
+// UsingTextsAndTokenizer creates a text classifier from labeled data and
+// custom tokenizer.
+func UsingTextsAndTokenizer(data []LabeledText, tokenizer Tokenizer) DataSource {
+	return func(model *Model) {
+		corpus := []labeledFeatures{}
+		for _, entry := range data {
+			corpus = append(corpus, labeledFeatures{
+				label:    entry.Label,
+				features: textFeatures(tokenizer.Tokenize(entry.Text))})
+		}
+		if len(corpus) == 0 {
+			model.err = errors.New("prose: no texts found in the training data")
+			return
+		}
+		model.classifier = classifierFromData(corpus, textIterations)
+	}
+}
+
+// Classification returns the category assigned to `doc` as a whole.
+func (doc *Document) Classification() Classification {
+	return doc.classification
+}
+
+// Classification returns the category assigned to `sent`.
+func (sent Sentence) Classification() Classification {
+	return sent.classification
+}
+
+// ClassifierComponent returns the built-in text classification stage, which
+// uses the Document's Model to categorize the Document and each of its
+// sentences.
+func ClassifierComponent() Component {
+	return classifierComponent{}
+}
+
+type classifierComponent struct{}
+
+func (classifierComponent) Name() string { return ClassifierName }
+
+func (classifierComponent) Process(doc *Document) error {
+	if doc.Model == nil || doc.Model.classifier == nil {
+		return errors.New("the document's model has no text classifier")
+	}
+	classifier := doc.Model.classifier
+
+	doc.classification = classifier.classify(doc.tokens)
+	for i := range doc.sentences {
+		doc.sentences[i].classification = classifier.classify(doc.sentences[i].tokens)
+	}
+	return nil
+}
+
+const (
+	// textIterations is the number of passes that we make over the training
+	// data.
+	textIterations = 20
+	// textLearningRate is the initial step size of gradient ascent.
+	textLearningRate = 0.5
+)
+
+// textClassifier is a maximum entropy (multinomial logistic regression)
+// classifier that categorizes texts by their words and word bigrams.
+//
+// It shares its representation (and on-disk format) with the NER's
+// binaryMaxentClassifier, with each joint-feature of the form
+// "contains(word)-True-label".
+type textClassifier struct {
+	model *binaryMaxentClassifier
+}
+
+type labeledFeatures struct {
+	label    string
+	features []string
+}
+
+// textFeatures returns the (sorted, unique) names of the input-features of a
+// text: its lowercased words and word bigrams.
+func textFeatures(tokens []*Token) []string {
+	seen := make(map[string]bool)
+
+	prev := ""
+	for _, tok := range tokens {
+		word := strings.ToLower(tok.Text)
+		seen["contains("+word+")"] = true
+		if prev != "" {
+			seen["contains("+prev+" "+word+")"] = true
+		}
+		prev = word
+	}
+
+	features := make([]string, 0, len(seen))
+	for name := range seen {
+		features = append(features, name)
+	}
+	sort.Strings(features)
+
+	return features
+}
+
+func textKey(feature, label string) string {
+	return strings.Join([]string{feature, "True", label}, "-")
+}
+
+// probs computes the probability of each label given `features`.
+func (c *textClassifier) probs(features []string) map[string]float64 {
+	scores := make(map[string]float64)
+	for _, label := range c.model.labels {
+		total := 0.0
+		for _, feature := range features {
+			if idx, found := c.model.mapping[textKey(feature, label)]; found {
+				total += c.model.weights[idx]
+			}
+		}
+		scores[label] = total
+	}
+
+	dist := newMappedProbDist(scores, true)
+	probs := make(map[string]float64)
+	for _, label := range c.model.labels {
+		probs[label] = dist.prob(label)
+	}
+	return probs
+}
+
+// classify categorizes the text made up of `tokens`.
+func (c *textClassifier) classify(tokens []*Token) Classification {
+	probs := c.probs(textFeatures(tokens))
+
+	best := ""
+	for _, label := range c.model.labels {
+		if best == "" || probs[label] > probs[best] {
+			best = label
+		}
+	}
+
+	return Classification{Label: best, Probs: probs}
+}
+
+// classifierFromData trains a textClassifier on `corpus` by (stochastic)
+// gradient ascent on its conditional log-likelihood.
+//
+// Unlike the NER, we don't use GIS: the number of active features varies
+// from text to text, which would make its correction feature dominate.
+func classifierFromData(corpus []labeledFeatures, iterations int) *textClassifier {
+	labels := []string{}
+	names := make(map[string]bool)
+	for _, entry := range corpus {
+		if !stringInSlice(entry.label, labels) {
+			labels = append(labels, entry.label)
+		}
+		for _, feature := range entry.features {
+			names[feature] = true
+		}
+	}
+
+	features := make([]string, 0, len(names))
+	for name := range names {
+		features = append(features, name)
+	}
+	sort.Strings(features)
+
+	mapping := make(map[string]int)
+	for _, feature := range features {
+		for _, label := range labels {
+			mapping[textKey(feature, label)] = len(mapping)
+		}
+	}
+
+	// The final weight belongs to GIS's correction feature, which we don't
+	// use.
+	weights := make([]float64, len(mapping)+1)
+	classifier := &textClassifier{model: newMaxentClassifier(weights, mapping, labels)}
+
+	for iter := 0; iter < iterations; iter++ {
+		rate := textLearningRate / math.Sqrt(float64(iter+1))
+		for _, entry := range corpus {
+			probs := classifier.probs(entry.features)
+			for _, label := range labels {
+				delta := -probs[label]
+				if label == entry.label {
+					delta++
+				}
+				for _, feature := range entry.features {
+					weights[mapping[textKey(feature, label)]] += rate * delta
+				}
+			}
+		}
+	}
+
+	return classifier
+}
+
+// loadTextClassifier reads a textClassifier from the "TextClassifier" folder
+// of a Model, if it has one.
+func loadTextClassifier(filesys fs.FS) (*textClassifier, error) {
+	if _, err := fs.Stat(filesys, "TextClassifier"); err != nil {
+		return nil, nil
+	}
+	model, err := loadMaxent(filesys, "TextClassifier")
+	if err != nil {
+		return nil, err
+	}
+	return &textClassifier{model: model}, nil
+}
@@ -0,0 +1,100 @@
+package prose
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var intents = []LabeledText{
+	{Text: "What is the weather like today?", Label: "weather"},
+	{Text: "Will it rain tomorrow?", Label: "weather"},
+	{Text: "Is it going to be sunny this weekend?", Label: "weather"},
+	{Text: "How cold is it outside?", Label: "weather"},
+	{Text: "What's the forecast for tonight?", Label: "weather"},
+	{Text: "Play some jazz music.", Label: "music"},
+	{Text: "Put on my favorite playlist.", Label: "music"},
+	{Text: "Skip this song.", Label: "music"},
+	{Text: "Turn up the music.", Label: "music"},
+	{Text: "Play the new album by Adele.", Label: "music"},
+	{Text: "Set an alarm for 7 am.", Label: "alarm"},
+	{Text: "Wake me up at six tomorrow.", Label: "alarm"},
+	{Text: "Cancel my morning alarm.", Label: "alarm"},
+	{Text: "Set a timer for ten minutes.", Label: "alarm"},
+	{Text: "Remind me to wake up early.", Label: "alarm"},
+}
+
+func TestTextClassifier(t *testing.T) {
+	model, err := TrainModelFromData("intents", UsingTexts(intents))
+	if err != nil {
+		t.Fatalf("TrainModelFromData() unexpected error: %v", err)
+	}
+
+	doc, err := NewDocument("Is it going to rain today? Play a song.",
+		UsingModel(model),
+		WithTagging(false),
+		WithExtraction(false),
+		WithClassification(true))
+	if err != nil {
+		t.Fatalf("NewDocument() unexpected error: %v", err)
+	}
+
+	expected := []string{"weather", "music"}
+	for i, sent := range doc.Sentences() {
+		class := sent.Classification()
+		if class.Label != expected[i] {
+			t.Errorf("Classification() expected = %v, got = %v (%v)",
+				expected[i], class.Label, class.Probs)
+		}
+
+		total := 0.0
+		for _, prob := range class.Probs {
+			total += prob
+		}
+		if len(class.Probs) != 3 || math.Abs(total-1) > 1e-9 {
+			t.Errorf("Classification() unexpected distribution: %v", class.Probs)
+		}
+	}
+	if doc.Classification().Label == "" {
+		t.Errorf("Classification() expected a document-level label")
+	}
+
+	temp := filepath.Join(testdata, "temp")
+	checkError(os.RemoveAll(temp))
+	checkError(model.Write(temp))
+	defer os.RemoveAll(temp)
+
+	loaded, err := LoadModelFromDisk(temp)
+	if err != nil {
+		t.Fatalf("LoadModelFromDisk() unexpected error: %v", err)
+	}
+
+	reloaded, err := NewDocument(doc.Text,
+		UsingModel(loaded),
+		WithTagging(false),
+		WithExtraction(false),
+		WithClassification(true))
+	checkError(err)
+
+	before, after := doc.Classification(), reloaded.Classification()
+	if before.Label != after.Label ||
+		math.Abs(before.Probs[before.Label]-after.Probs[after.Label]) > 1e-9 {
+		t.Errorf("LoadModelFromDisk() expected = %v, got = %v", before, after)
+	}
+}
+
+func TestTextClassifierMissing(t *testing.T) {
+	_, err := NewDocument("Hello.",
+		UsingModel(&Model{}),
+		WithTagging(false),
+		WithExtraction(false),
+		WithClassification(true))
+	if err == nil {
+		t.Errorf("NewDocument() expected an error without a text classifier")
+	}
+
+	if _, err = TrainModelFromData("empty", UsingTexts(nil)); err == nil {
+		t.Errorf("TrainModelFromData() expected an error without any texts")
+	}
+}
@@ -0,0 +1,429 @@
+package prose
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// corpusVersion is the version of the JSON schema used to serialize Corpora.
+const corpusVersion = 1
+
+// TermNormalization determines how a Corpus turns tokens into terms.
+type TermNormalization int
+
+const (
+	// Lowercased terms are the tokens' lowercased text.
+	Lowercased TermNormalization = iota
+	// Lemmatized terms are the tokens' lemmas (see Lemmatize).
+	Lemmatized
+	// Stemmed terms are the tokens' stems (see Stem).
+	Stemmed
+)
+
+// CorpusOpts controls how a Corpus indexes its Documents:
+type CorpusOpts struct {
+	Normalization TermNormalization // How tokens become terms
+	Stopwords     []string          // If non-nil, words to leave out
+	K1            float64           // BM25's term-frequency saturation (1.2)
+	B             float64           // BM25's length normalization (0.75)
+}
+
+// A CorpusOpt represents a setting that changes how a Corpus is indexed.
+type CorpusOpt func(opts *CorpusOpts)
+
+// UsingNormalization specifies how tokens are turned into terms.
+//
+// Lemmatized terms rely on POS tags: if a Document doesn't have lemmas (see
+// WithLemmatization), they're computed from its tokens' tags.
+func UsingNormalization(norm TermNormalization) CorpusOpt {
+	return func(opts *CorpusOpts) {
+		opts.Normalization = norm
+	}
+}
+
+// WithStopwordRemoval leaves `words` (e.g., EnglishStopwords()) out of the
+// index.
+func WithStopwordRemoval(words []string) CorpusOpt {
+	return func(opts *CorpusOpts) {
+		opts.Stopwords = words
+	}
+}
+
+// WithBM25Params specifies BM25's `k1` and `b` parameters.
+func WithBM25Params(k1, b float64) CorpusOpt {
+	return func(opts *CorpusOpts) {
+		opts.K1, opts.B = k1, b
+	}
+}
+
+// A SearchResult identifies a Document within a Corpus, along with how well
+// it matches a query.
+type SearchResult struct {
+	Index int     // The Document's position within the Corpus.
+	Score float64 // The Document's BM25 score or cosine similarity.
+}
+
+// A Corpus is a collection of Documents, indexed by the terms that they
+// contain.
+//
+// It supports BM25 search and cosine similarity between TF-IDF vectors. A
+// Corpus may be serialized with encoding/json, including its Documents and
+// index, so that it only has to be built once.
+//
+// Once built, a Corpus is safe for concurrent use by multiple goroutines,
+// but Add (and UnmarshalJSON) mustn't be called concurrently with any other
+// method.
+type Corpus struct {
+	opts      CorpusOpts
+	stopwords map[string]bool
+
+	docs  []*Document
+	terms []map[int]int // The frequency of each term in each Document.
+	sizes []int         // The number of terms in each Document.
+	total int           // The total number of terms.
+
+	ids      map[string]int
+	vocab    []string
+	postings [][]int // The Documents that contain each term.
+
+	normsMu sync.Mutex
+	norms   []float64 // The length of each TF-IDF vector, computed lazily.
+}
+
+// NewCorpus creates an empty Corpus.
+func NewCorpus(opts ...CorpusOpt) *Corpus {
+	base := CorpusOpts{Normalization: Lowercased, K1: 1.2, B: 0.75}
+	for _, applyOpt := range opts {
+		applyOpt(&base)
+	}
+
+	return &Corpus{
+		opts:      base,
+		stopwords: stopwordSet(base.Stopwords),
+		ids:       make(map[string]int),
+	}
+}
+
+// Add indexes `doc`, returning its position within the Corpus.
+func (c *Corpus) Add(doc *Document) int {
+	counts := make(map[int]int)
+	size := 0
+	for _, term := range c.normalize(doc.tokens) {
+		counts[c.intern(term)]++
+		size++
+	}
+	return c.add(doc, counts, size)
+}
+
+// Len returns the number of Documents in `c`.
+func (c *Corpus) Len() int {
+	return len(c.docs)
+}
+
+// Document returns the Document at position `i`.
+func (c *Corpus) Document(i int) *Document {
+	return c.docs[i]
+}
+
+// Vocabulary returns the terms that occur in `c`, sorted alphabetically.
+func (c *Corpus) Vocabulary() []string {
+	vocab := make([]string, len(c.vocab))
+	copy(vocab, c.vocab)
+	sort.Strings(vocab)
+	return vocab
+}
+
+// DocumentFrequency returns the number of Documents that contain `term`
+// (which should already be normalized).
+func (c *Corpus) DocumentFrequency(term string) int {
+	if id, found := c.ids[term]; found {
+		return len(c.postings[id])
+	}
+	return 0
+}
+
+// Vector returns the TF-IDF vector of `doc` (which needn't belong to `c`),
+// mapping each of its terms to its weight.
+//
+// Weights are raw term frequencies times smoothed inverse document
+// frequencies, ln((1 + N) / (1 + df)) + 1, and the vector is normalized to
+// unit length. Terms that don't occur in the Corpus are left out.
+func (c *Corpus) Vector(doc *Document) map[string]float64 {
+	counts := make(map[int]int)
+	for _, term := range c.normalize(doc.tokens) {
+		if id, found := c.ids[term]; found {
+			counts[id]++
+		}
+	}
+
+	weights := c.weigh(counts)
+	vector := make(map[string]float64, len(weights))
+	for id, weight := range weights {
+		vector[c.vocab[id]] = weight
+	}
+	return vector
+}
+
+// Similar returns the `k` Documents whose TF-IDF vectors are the most similar
+// to that of `doc`, by cosine similarity.
+//
+// Documents that don't share any terms with `doc` aren't included.
+func (c *Corpus) Similar(doc *Document, k int) []SearchResult {
+	counts := make(map[int]int)
+	for _, term := range c.normalize(doc.tokens) {
+		if id, found := c.ids[term]; found {
+			counts[id]++
+		}
+	}
+
+	norms := c.vectorNorms()
+	scores := make(map[int]float64)
+	for id, weight := range c.weigh(counts) {
+		idf := c.idf(id)
+		for _, idx := range c.postings[id] {
+			scores[idx] += weight * float64(c.terms[idx][id]) * idf / norms[idx]
+		}
+	}
+
+	return topResults(scores, k)
+}
+
+// Search returns the `k` Documents that best match `query`, ranked by BM25.
+//
+// The query is tokenized (and, if needed, tagged and lemmatized) in the same
+// way as a Document.
+func (c *Corpus) Search(query string, k int) ([]SearchResult, error) {
+	lemmatize := c.opts.Normalization == Lemmatized
+	doc, err := NewDocument(query,
+		WithSegmentation(false),
+		WithExtraction(false),
+		WithTagging(lemmatize),
+		WithLemmatization(lemmatize))
+	if err != nil {
+		return nil, err
+	}
+
+	n := float64(len(c.docs))
+	avgSize := float64(c.total) / math.Max(n, 1)
+
+	scores := make(map[int]float64)
+	for _, term := range c.normalize(doc.tokens) {
+		id, found := c.ids[term]
+		if !found {
+			continue
+		}
+
+		df := float64(len(c.postings[id]))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for _, idx := range c.postings[id] {
+			tf := float64(c.terms[idx][id])
+			norm := c.opts.K1 * (1 - c.opts.B + c.opts.B*float64(c.sizes[idx])/avgSize)
+			scores[idx] += idf * tf * (c.opts.K1 + 1) / (tf + norm)
+		}
+	}
+
+	return topResults(scores, k), nil
+}
+
+// normalize turns `tokens` into terms, leaving out punctuation and
+// stopwords.
+func (c *Corpus) normalize(tokens []*Token) []string {
+	terms := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		word := strings.ToLower(tok.Text)
+		if c.stopwords[word] || isPunct(word) {
+			continue
+		}
+
+		switch c.opts.Normalization {
+		case Lemmatized:
+			lemma := tok.Lemma
+			if lemma == "" {
+				lemma = Lemmatize(tok.Text, tok.Tag)
+			}
+			word = strings.ToLower(lemma)
+		case Stemmed:
+			word = tok.Stem
+			if word == "" {
+				word = Stem(tok.Text)
+			}
+		}
+
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+func (c *Corpus) add(doc *Document, counts map[int]int, size int) int {
+	idx := len(c.docs)
+	for id := range counts {
+		c.postings[id] = append(c.postings[id], idx)
+	}
+
+	c.docs = append(c.docs, doc)
+	c.terms = append(c.terms, counts)
+	c.sizes = append(c.sizes, size)
+	c.total += size
+	c.norms = nil
+
+	return idx
+}
+
+func (c *Corpus) intern(term string) int {
+	if id, found := c.ids[term]; found {
+		return id
+	}
+	id := len(c.vocab)
+	c.ids[term] = id
+	c.vocab = append(c.vocab, term)
+	c.postings = append(c.postings, nil)
+	return id
+}
+
+func (c *Corpus) idf(id int) float64 {
+	n, df := float64(len(c.docs)), float64(len(c.postings[id]))
+	return math.Log((1+n)/(1+df)) + 1
+}
+
+// weigh computes the (unit-length) TF-IDF vector of the given term counts.
+func (c *Corpus) weigh(counts map[int]int) map[int]float64 {
+	weights := make(map[int]float64, len(counts))
+	norm := 0.0
+	for id, count := range counts {
+		weights[id] = float64(count) * c.idf(id)
+		norm += weights[id] * weights[id]
+	}
+
+	norm = math.Sqrt(norm)
+	for id := range weights {
+		weights[id] /= norm
+	}
+	return weights
+}
+
+// vectorNorms returns the length of each Document's (unnormalized) TF-IDF
+// vector, which changes whenever a Document is added.
+func (c *Corpus) vectorNorms() []float64 {
+	c.normsMu.Lock()
+	defer c.normsMu.Unlock()
+
+	if c.norms != nil {
+		return c.norms
+	}
+
+	c.norms = make([]float64, len(c.docs))
+	for idx, counts := range c.terms {
+		norm := 0.0
+		for id, count := range counts {
+			weight := float64(count) * c.idf(id)
+			norm += weight * weight
+		}
+		c.norms[idx] = math.Max(math.Sqrt(norm), smallValue)
+	}
+	return c.norms
+}
+
+// topResults returns the `k` highest-scoring Documents, breaking ties by
+// position.
+func topResults(scores map[int]float64, k int) []SearchResult {
+	results := make([]SearchResult, 0, len(scores))
+	for idx, score := range scores {
+		results = append(results, SearchResult{Index: idx, Score: score})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Index < results[j].Index
+	})
+
+	if k >= 0 && k < len(results) {
+		results = results[:k]
+	}
+	return results
+}
+
+// jsonCorpus is the serialized form of a Corpus.
+type jsonCorpus struct {
+	Version       int         `json:"version"`
+	Normalization int         `json:"normalization"`
+	Stopwords     []string    `json:"stopwords,omitempty"`
+	K1            float64     `json:"k1"`
+	B             float64     `json:"b"`
+	Vocabulary    []string    `json:"vocabulary"`
+	Documents     []*Document `json:"documents"`
+	// Terms holds the (term, frequency) pairs of each Document.
+	Terms [][][2]int `json:"terms"`
+}
+
+// MarshalJSON encodes `c`, including its options, Documents and index, as
+// JSON.
+func (c *Corpus) MarshalJSON() ([]byte, error) {
+	out := jsonCorpus{
+		Version:       corpusVersion,
+		Normalization: int(c.opts.Normalization),
+		Stopwords:     c.opts.Stopwords,
+		K1:            c.opts.K1,
+		B:             c.opts.B,
+		Vocabulary:    c.vocab,
+		Documents:     c.docs,
+		Terms:         make([][][2]int, len(c.terms)),
+	}
+	if out.Vocabulary == nil {
+		out.Vocabulary = []string{}
+	}
+
+	for idx, counts := range c.terms {
+		pairs := make([][2]int, 0, len(counts))
+		for id, count := range counts {
+			pairs = append(pairs, [2]int{id, count})
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+		out.Terms[idx] = pairs
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON rebuilds a Corpus from the output of MarshalJSON, without
+// re-processing its Documents.
+func (c *Corpus) UnmarshalJSON(data []byte) error {
+	var in jsonCorpus
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in.Version > corpusVersion {
+		return fmt.Errorf("prose: unsupported corpus version %d", in.Version)
+	} else if len(in.Documents) != len(in.Terms) {
+		return fmt.Errorf("prose: corpus has %d documents but %d term lists",
+			len(in.Documents), len(in.Terms))
+	}
+
+	*c = *NewCorpus(
+		UsingNormalization(TermNormalization(in.Normalization)),
+		WithStopwordRemoval(in.Stopwords),
+		WithBM25Params(in.K1, in.B))
+
+	for _, term := range in.Vocabulary {
+		c.intern(term)
+	}
+	for idx, doc := range in.Documents {
+		counts := make(map[int]int, len(in.Terms[idx]))
+		size := 0
+		for _, pair := range in.Terms[idx] {
+			if pair[0] < 0 || pair[0] >= len(c.vocab) {
+				return fmt.Errorf("prose: corpus term %d out of range", pair[0])
+			}
+			counts[pair[0]] = pair[1]
+			size += pair[1]
+		}
+		c.add(doc, counts, size)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package prose
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+var corpusTexts = []string{
+	"The printer is out of paper and jams constantly.",
+	"My laptop battery drains quickly after the update.",
+	"How do I connect the printer to the office network?",
+	"The update broke the network drivers on my laptop.",
+	"Battery life is great on the new phones.",
+}
+
+func makeTestCorpus(opts ...CorpusOpt) *Corpus {
+	corpus := NewCorpus(opts...)
+	for _, text := range corpusTexts {
+		doc, err := NewDocument(text, WithTagging(false), WithExtraction(false))
+		checkError(err)
+		corpus.Add(doc)
+	}
+	return corpus
+}
+
+func resultIndices(results []SearchResult) []int {
+	indices := []int{}
+	for _, result := range results {
+		indices = append(indices, result.Index)
+	}
+	return indices
+}
+
+func TestCorpusSearch(t *testing.T) {
+	corpus := makeTestCorpus(WithStopwordRemoval(EnglishStopwords()))
+	if corpus.Len() != 5 || corpus.DocumentFrequency("printer") != 2 ||
+		corpus.DocumentFrequency("the") != 0 {
+		t.Errorf("NewCorpus() unexpected index: %v", corpus.Vocabulary())
+	}
+
+	results, err := corpus.Search("printer network", 3)
+	checkError(err)
+	if indices := resultIndices(results); !reflect.DeepEqual(indices, []int{2, 0, 3}) {
+		t.Errorf("Search() expected = [2 0 3], got = %v (%v)", indices, results)
+	}
+
+	results, err = corpus.Search("spreadsheet", 3)
+	checkError(err)
+	if len(results) != 0 {
+		t.Errorf("Search() expected no results, got = %v", results)
+	}
+}
+
+func TestCorpusNormalization(t *testing.T) {
+	stemmed := makeTestCorpus(UsingNormalization(Stemmed))
+	if stemmed.DocumentFrequency("jam") != 1 || stemmed.DocumentFrequency("quick") != 1 {
+		t.Errorf("UsingNormalization(Stemmed) unexpected vocabulary: %v", stemmed.Vocabulary())
+	}
+
+	results, err := stemmed.Search("jamming printers", 1)
+	checkError(err)
+	if len(results) != 1 || results[0].Index != 0 {
+		t.Errorf("Search() expected the first document, got = %v", results)
+	}
+
+	lemmatized := NewCorpus(UsingNormalization(Lemmatized))
+	lemmatized.Add(makeTagged("The|DT mice|NNS were|VBD running|VBG .|."))
+	for _, term := range []string{"mouse", "be", "run"} {
+		if lemmatized.DocumentFrequency(term) != 1 {
+			t.Errorf("UsingNormalization(Lemmatized) expected %q in %v",
+				term, lemmatized.Vocabulary())
+		}
+	}
+}
+
+func TestCorpusSimilarity(t *testing.T) {
+	corpus := makeTestCorpus(WithStopwordRemoval(EnglishStopwords()))
+
+	vector := corpus.Vector(corpus.Document(1))
+	norm := 0.0
+	for _, weight := range vector {
+		norm += weight * weight
+	}
+	if math.Abs(norm-1) > 1e-9 || vector["battery"] <= 0 {
+		t.Errorf("Vector() expected a unit-length vector, got = %v", vector)
+	}
+
+	results := corpus.Similar(corpus.Document(1), 2)
+	if indices := resultIndices(results); !reflect.DeepEqual(indices, []int{1, 3}) {
+		t.Errorf("Similar() expected = [1 3], got = %v (%v)", indices, results)
+	}
+	if math.Abs(results[0].Score-1) > 1e-9 {
+		t.Errorf("Similar() expected a document to be identical to itself, got = %v", results[0])
+	}
+}
+
+func TestCorpusConcurrentSimilar(t *testing.T) {
+	corpus := makeTestCorpus()
+	doc, err := NewDocument("The printer network is down.", WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	corpus.Similar(doc, 3)
+	corpus.Add(doc) // Invalidates the cached vector lengths.
+
+	var wg sync.WaitGroup
+	results := make([][]SearchResult, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = corpus.Similar(doc, 1)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if len(result) != 1 || result[0].Index != 5 {
+			t.Errorf("Similar() expected the document itself, got = %v", result)
+		}
+	}
+}
+
+func TestCorpusJSON(t *testing.T) {
+	corpus := makeTestCorpus(UsingNormalization(Stemmed), WithBM25Params(1.5, 0.5))
+
+	data, err := json.Marshal(corpus)
+	checkError(err)
+
+	var loaded Corpus
+	checkError(json.Unmarshal(data, &loaded))
+
+	if loaded.Len() != corpus.Len() || loaded.Document(2).Text != corpusTexts[2] ||
+		!reflect.DeepEqual(loaded.Vocabulary(), corpus.Vocabulary()) {
+		t.Fatalf("UnmarshalJSON() didn't restore the corpus")
+	}
+
+	before, err := corpus.Search("laptop update", -1)
+	checkError(err)
+	after, err := loaded.Search("laptop update", -1)
+	checkError(err)
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("UnmarshalJSON() expected = %v, got = %v", before, after)
+	}
+
+	if err = json.Unmarshal([]byte(`{"version": 99}`), &loaded); err == nil {
+		t.Errorf("UnmarshalJSON() expected an error for an unsupported version")
+	}
+}
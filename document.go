@@ -1,5 +1,10 @@
 package prose
 
+import (
+	"context"
+	"sort"
+)
+
 // A DocOpt represents a setting that changes the document creation process.
 //
 // For example, it might disable named-entity extraction:
@@ -9,10 +14,21 @@ type DocOpt func(doc *Document, opts *DocOpts)
 
 // DocOpts controls the Document creation process:
 type DocOpts struct {
-	Extract  bool // If true, include named-entity extraction
-	Segment  bool // If true, include segmentation
-	Tag      bool // If true, include POS tagging
+	Classify  bool      // If true, include text classification
+	Extract   bool      // If true, include named-entity extraction
+	Lemmatize bool      // If true, include lemmatization
+	Parse     bool      // If true, include dependency parsing
+	Segment   bool      // If true, include segmentation
+	Sentiment bool      // If true, include sentiment analysis
+	Stem      bool      // If true, include stemming
+	Tag       bool      // If true, include POS tagging
 	Tokenizer Tokenizer // If true, include tokenization
+
+	// Pipeline, if non-nil, replaces the Components implied by the settings
+	// above (see UsingPipeline).
+	Pipeline []Component
+
+	edits []pipelineEdit
 }
 
 // UsingTokenizer specifies the Tokenizer to use.
@@ -54,6 +70,52 @@ func WithExtraction(include bool) DocOpt {
 	}
 }
 
+// WithLemmatization can enable or disable (the default) lemmatization.
+//
+// Lemmatization relies on POS tags, so enabling it also enables tagging.
+func WithLemmatization(include bool) DocOpt {
+	return func(doc *Document, opts *DocOpts) {
+		opts.Lemmatize = include
+	}
+}
+
+// WithStemming can enable or disable (the default) stemming.
+func WithStemming(include bool) DocOpt {
+	return func(doc *Document, opts *DocOpts) {
+		opts.Stem = include
+	}
+}
+
+// WithParsing can enable or disable (the default) dependency parsing.
+//
+// Parsing relies on POS tags, so enabling it also enables tagging. It uses
+// a custom Model's dependency parser (see UsingDependencies and UsingModel)
+// or, otherwise, the built-in model's, which must have been trained on UD
+// English EWT with `make parser`.
+func WithParsing(include bool) DocOpt {
+	return func(doc *Document, opts *DocOpts) {
+		opts.Parse = include
+	}
+}
+
+// WithClassification can enable or disable (the default) text
+// classification.
+//
+// Classification requires a Model that includes a text classifier (see
+// UsingTexts).
+func WithClassification(include bool) DocOpt {
+	return func(doc *Document, opts *DocOpts) {
+		opts.Classify = include
+	}
+}
+
+// WithSentiment can enable or disable (the default) sentiment analysis.
+func WithSentiment(include bool) DocOpt {
+	return func(doc *Document, opts *DocOpts) {
+		opts.Sentiment = include
+	}
+}
+
 // UsingModel can enable (the default) or disable named-entity extraction.
 func UsingModel(model *Model) DocOpt {
 	return func(doc *Document, opts *DocOpts) {
@@ -66,10 +128,14 @@ type Document struct {
 	Model *Model
 	Text  string
 
-	// TODO: Store offsets (begin, end) instead of `text` field.
 	entities  []Entity
 	sentences []Sentence
 	tokens    []*Token
+
+	classification Classification
+	sentiment      Sentiment
+
+	ctx context.Context
 }
 
 // Tokens returns `doc`'s tokens.
@@ -93,9 +159,9 @@ func (doc *Document) Entities() []Entity {
 
 var defaultOpts = DocOpts{
 	Tokenizer: NewIterTokenizer(),
-	Segment:  true,
-	Tag:      true,
-	Extract:  true,
+	Segment:   true,
+	Tag:       true,
+	Extract:   true,
 }
 
 // NewDocument creates a Document according to the user-specified options.
@@ -104,32 +170,146 @@ var defaultOpts = DocOpts{
 //
 //    doc := prose.NewDocument("...")
 func NewDocument(text string, opts ...DocOpt) (*Document, error) {
+	return NewDocumentContext(context.Background(), text, opts...)
+}
+
+// NewDocumentContext is NewDocument, but it stops processing `text` once
+// `ctx` is done.
+//
+// The context is checked between pipeline stages and periodically during
+// tokenization, tagging, and named-entity extraction. If it's cancelled (or
+// its deadline passes), NewDocumentContext returns the partially-processed
+// Document along with `ctx.Err()`.
+func NewDocumentContext(ctx context.Context, text string, opts ...DocOpt) (*Document, error) {
 	var pipeError error
 
-	doc := Document{Text: text}
+	doc := Document{Text: text, ctx: ctx}
 	base := defaultOpts
 	for _, applyOpt := range opts {
 		applyOpt(&doc, &base)
 	}
 
+	pipeline, pipeError := base.pipeline()
+	if pipeError != nil {
+		return &doc, &StageError{Stage: "pipeline", Err: pipeError}
+	}
+
 	if doc.Model == nil {
-		doc.Model = defaultModel(base.Tag, base.Extract)
+		doc.Model, pipeError = defaultModel(requirements(pipeline))
+		if pipeError != nil {
+			return &doc, &StageError{Stage: "model", Err: pipeError}
+		}
 	}
 
-	if base.Segment {
-		segmenter := newPunktSentenceTokenizer()
-		doc.sentences = segmenter.segment(text)
+	for _, component := range pipeline {
+		if pipeError = ctx.Err(); pipeError != nil {
+			break
+		}
+		if err := component.Process(&doc); err != nil {
+			if pipeError = ctx.Err(); pipeError == nil {
+				pipeError = &StageError{Stage: component.Name(), Err: err}
+			}
+			break
+		}
 	}
-	if base.Tokenizer != nil {
-		doc.tokens = append(doc.tokens, base.Tokenizer.Tokenize(text)...)
+
+	return &doc, pipeError
+}
+
+// Context returns the context.Context that `doc` is being processed under.
+//
+// Long-running Components should stop early once it's done.
+func (doc *Document) Context() context.Context {
+	if doc.ctx == nil {
+		return context.Background()
 	}
-	if base.Tag || base.Extract {
-		doc.tokens = doc.Model.tagger.tag(doc.tokens)
+	return doc.ctx
+}
+
+// MutableTokens returns pointers to `doc`'s tokens.
+//
+// Unlike Tokens, changes made through the returned pointers are reflected in
+// `doc` itself, which makes this the natural choice for a Component that
+// annotates existing tokens.
+func (doc *Document) MutableTokens() []*Token {
+	return doc.tokens
+}
+
+// SetTokens replaces `doc`'s tokens, assigning each of them to the sentence
+// that contains it.
+func (doc *Document) SetTokens(tokens []*Token) {
+	doc.tokens = tokens
+	doc.groupTokens()
+}
+
+// SetSentences replaces `doc`'s sentences, re-assigning its existing tokens
+// and entities to them.
+func (doc *Document) SetSentences(sents []Sentence) {
+	doc.sentences = sents
+	doc.groupTokens()
+	doc.groupEntities()
+}
+
+// SetEntities replaces `doc`'s named-entities, assigning each of them to the
+// sentence that contains it.
+func (doc *Document) SetEntities(entities []Entity) {
+	doc.entities = entities
+	doc.groupEntities()
+}
+
+// tokenGroups returns `doc`'s tokens grouped by sentence.
+//
+// If `doc` hasn't been segmented, all of its tokens form a single group.
+func (doc *Document) tokenGroups() [][]*Token {
+	if len(doc.sentences) == 0 {
+		return [][]*Token{doc.tokens}
 	}
-	if base.Extract {
-		doc.tokens = doc.Model.extracter.classify(doc.tokens)
-		doc.entities = doc.Model.extracter.chunk(doc.tokens)
+	groups := make([][]*Token, len(doc.sentences))
+	for i := range doc.sentences {
+		groups[i] = doc.sentences[i].tokens
 	}
+	return groups
+}
 
-	return &doc, pipeError
+// groupTokens assigns each of `doc`'s tokens to the sentence that contains
+// it.
+func (doc *Document) groupTokens() {
+	if len(doc.sentences) == 0 {
+		return
+	}
+
+	idx, start := 0, 0
+	for i := range doc.sentences {
+		doc.sentences[i].tokens = nil
+	}
+	for i, tok := range doc.tokens {
+		for idx < len(doc.sentences)-1 && tok.Start >= doc.sentences[idx+1].Start {
+			doc.sentences[idx].tokens = doc.tokens[start:i:i]
+			start = i
+			idx++
+		}
+	}
+	doc.sentences[idx].tokens = doc.tokens[start:len(doc.tokens):len(doc.tokens)]
+}
+
+// groupEntities assigns each of `doc`'s entities to the sentence that
+// contains it.
+func (doc *Document) groupEntities() {
+	for i := range doc.sentences {
+		doc.sentences[i].entities = nil
+	}
+	for _, ent := range doc.entities {
+		if idx := doc.sentenceAt(ent.Start); idx >= 0 {
+			doc.sentences[idx].entities = append(doc.sentences[idx].entities, ent)
+		}
+	}
+}
+
+// sentenceAt returns the index of the sentence containing the byte offset
+// `offset`, or -1 if `doc` hasn't been segmented.
+func (doc *Document) sentenceAt(offset int) int {
+	idx := sort.Search(len(doc.sentences), func(i int) bool {
+		return doc.sentences[i].Start > offset
+	})
+	return idx - 1
 }
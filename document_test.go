@@ -1,11 +1,116 @@
 package prose
 
 import (
+	"context"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
 
+func TestSentenceTokens(t *testing.T) {
+	doc, err := NewDocument(
+		"I can see Mt. Fuji from here.  St. Michael's Church is on 5th st. near the light.",
+		WithTagging(false),
+		WithExtraction(false))
+	if err != nil {
+		panic(err)
+	}
+
+	expected := [][]string{
+		{"I", "can", "see", "Mt.", "Fuji", "from", "here", "."},
+		{"St.", "Michael", "'s", "Church", "is", "on", "5th", "st", ".", "near",
+			"the", "light", "."},
+	}
+
+	sents := doc.Sentences()
+	if len(sents) != len(expected) {
+		t.Fatalf("SentenceTokens() expected = %v sentences, got = %v",
+			len(expected), len(sents))
+	}
+
+	for i, sent := range sents {
+		observed := []string{}
+		for _, tok := range sent.Tokens() {
+			observed = append(observed, tok.Text)
+		}
+		if !reflect.DeepEqual(observed, expected[i]) {
+			t.Errorf("SentenceTokens() got = %v", observed)
+		}
+	}
+}
+
+func TestSentenceEntities(t *testing.T) {
+	doc, err := NewDocument(
+		"Windows 10 is an operating system. I prefer Windows 10 to macOS.",
+		UsingModel(ModelFromDisk(filepath.Join(testdata, "PRODUCT"))))
+	if err != nil {
+		panic(err)
+	}
+
+	var entities []Entity
+	for _, sent := range doc.Sentences() {
+		for _, ent := range sent.Entities() {
+			if ent.Start < sent.Start || ent.End > sent.End {
+				t.Errorf("SentenceEntities() %v is outside of %v", ent, sent.Text)
+			}
+			entities = append(entities, ent)
+		}
+	}
+
+	if !reflect.DeepEqual(entities, doc.Entities()) {
+		t.Errorf("SentenceEntities() expected = %v, got = %v",
+			doc.Entities(), entities)
+	}
+}
+
+// canceler is a Component that cancels the Document's context.
+type canceler struct {
+	cancel context.CancelFunc
+}
+
+func (c canceler) Name() string { return "canceler" }
+
+func (c canceler) Process(doc *Document) error {
+	c.cancel()
+	return nil
+}
+
+func TestNewDocumentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	doc, err := NewDocumentContext(ctx, "Hello World. My name is Jonas.",
+		UsingPipeline(
+			TokenizerComponent(NewIterTokenizer()),
+			canceler{cancel: cancel},
+			TaggerComponent()))
+
+	if err != context.Canceled {
+		t.Errorf("NewDocumentContext() expected = %v, got = %v", context.Canceled, err)
+	}
+
+	tokens := doc.Tokens()
+	if len(tokens) != 8 {
+		t.Fatalf("NewDocumentContext() expected = 8 tokens, got = %v", len(tokens))
+	}
+	for _, tok := range tokens {
+		if tok.Tag != "" {
+			t.Errorf("NewDocumentContext() unexpected tag: %v", tok)
+		}
+	}
+
+	text := string(readDataFile(filepath.Join(testdata, "sherlock.txt")))
+	doc, err = NewDocumentContext(ctx, text)
+	if err != context.Canceled || len(doc.Tokens()) != 0 {
+		t.Errorf("NewDocumentContext() expected no work to be done, got = %v", err)
+	}
+
+	tokenizer := NewIterTokenizer()
+	partial, err := tokenizer.tokenizeContext(ctx, text)
+	if err != context.Canceled || len(partial) >= checkInterval {
+		t.Errorf("tokenizeContext() expected to stop early, got = %v tokens", len(partial))
+	}
+}
+
 func BenchmarkDoc(b *testing.B) {
 	content := readDataFile(filepath.Join(testdata, "sherlock.txt"))
 	text := string(content)
@@ -17,6 +122,15 @@ func BenchmarkDoc(b *testing.B) {
 	}
 }
 
+func BenchmarkDocShort(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_, err := NewDocument("Go is an open-source programming language created at Google.")
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
 func BenchmarkCustomTokenizer(b *testing.B) {
 	content := readDataFile(filepath.Join(testdata, "sherlock.txt"))
 	tok := NewIterTokenizer(
@@ -0,0 +1,29 @@
+package prose
+
+// An AssetError records a failure to load or save one of a Model's assets.
+type AssetError struct {
+	Op    string // The failed operation: "load" or "save".
+	Asset string // The asset's path relative to the model (e.g., "Maxent/labels.gob").
+	Err   error  // The underlying error.
+}
+
+func (e *AssetError) Error() string {
+	return e.Op + " " + e.Asset + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *AssetError) Unwrap() error { return e.Err }
+
+// A StageError records a failure in one stage of the Document-creation
+// process.
+type StageError struct {
+	Stage string // The stage's name (e.g., TaggerName or "model").
+	Err   error  // The underlying error.
+}
+
+func (e *StageError) Error() string {
+	return "prose: " + e.Stage + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *StageError) Unwrap() error { return e.Err }
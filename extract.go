@@ -1,7 +1,7 @@
 package prose
 
 import (
-	"encoding/gob"
+	"context"
 	"math"
 	"os"
 	"path/filepath"
@@ -106,22 +106,19 @@ func newMaxentClassifier(
 		weights}
 }
 
-// marshal saves the model to disk.
-func (m *binaryMaxentClassifier) marshal(path string) error {
-	folder := filepath.Join(path, "Maxent")
-	err := os.Mkdir(folder, os.ModePerm)
-	for i, entry := range []string{"labels", "mapping", "weights"} {
-		component, _ := os.Create(filepath.Join(folder, entry+".gob"))
-		encoder := gob.NewEncoder(component)
-		if i == 0 {
-			checkError(encoder.Encode(m.labels))
-		} else if i == 1 {
-			checkError(encoder.Encode(m.mapping))
-		} else {
-			checkError(encoder.Encode(m.weights))
-		}
+// marshal saves the model to the folder `folder` (e.g., "Maxent") under
+// `path`.
+func (m *binaryMaxentClassifier) marshal(path, folder string) error {
+	if err := os.MkdirAll(filepath.Join(path, folder), os.ModePerm); err != nil {
+		return assetError("save", folder, err)
+	}
+	if err := saveDiskAsset(path, folder, "labels.gob", m.labels); err != nil {
+		return err
+	}
+	if err := saveDiskAsset(path, folder, "mapping.gob", m.mapping); err != nil {
+		return err
 	}
-	return err
+	return saveDiskAsset(path, folder, "weights.gob", m.weights)
 }
 
 // entityExtracter is a maximum entropy classifier.
@@ -133,21 +130,22 @@ type entityExtracter struct {
 }
 
 // newEntityExtracter creates a new entityExtracter using the default model.
-func newEntityExtracter() *entityExtracter {
+func newEntityExtracter() (*entityExtracter, error) {
 	var mapping map[string]int
 	var weights []float64
 	var labels []string
 
-	dec := getAsset("Maxent", "mapping.gob")
-	checkError(dec.Decode(&mapping))
-
-	dec = getAsset("Maxent", "weights.gob")
-	checkError(dec.Decode(&weights))
-
-	dec = getAsset("Maxent", "labels.gob")
-	checkError(dec.Decode(&labels))
+	if err := loadAsset("Maxent", "mapping.gob", &mapping); err != nil {
+		return nil, err
+	}
+	if err := loadAsset("Maxent", "weights.gob", &weights); err != nil {
+		return nil, err
+	}
+	if err := loadAsset("Maxent", "labels.gob", &labels); err != nil {
+		return nil, err
+	}
 
-	return &entityExtracter{model: newMaxentClassifier(weights, mapping, labels)}
+	return &entityExtracter{model: newMaxentClassifier(weights, mapping, labels)}, nil
 }
 
 // newTrainedEntityExtracter creates a new EntityExtracter using the given
@@ -348,9 +346,21 @@ func estCount(
 }
 
 func (e *entityExtracter) classify(tokens []*Token) []*Token {
+	labeled, _ := e.classifyContext(context.Background(), tokens)
+	return labeled
+}
+
+// classifyContext is classify, but it stops early (returning only the tokens
+// that have been labeled) if `ctx` is done.
+func (e *entityExtracter) classifyContext(ctx context.Context, tokens []*Token) ([]*Token, error) {
 	length := len(tokens)
 	history := make([]string, 0, length)
 	for i := 0; i < length; i++ {
+		if i%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return tokens[:i], err
+			}
+		}
 		scores := make(map[string]float64)
 		features := extract(i, tokens, history)
 		for _, label := range e.model.labels {
@@ -364,7 +374,7 @@ func (e *entityExtracter) classify(tokens []*Token) []*Token {
 		tokens[i].Label = label
 		history = append(history, simplePOS(label))
 	}
-	return tokens
+	return tokens, nil
 }
 
 func (e *entityExtracter) probClassify(features map[string]string) *mappedProbDist {
@@ -398,12 +408,25 @@ func coalesce(parts []*Token) Entity {
 		tokens[i] = tok.Text
 		labels[i] = tok.Label
 	}
+	first, last := parts[0], parts[length-1]
 	return Entity{
+		Span: Span{
+			Start:     first.Start,
+			End:       last.End,
+			RuneStart: first.RuneStart,
+			RuneEnd:   last.RuneEnd},
 		Label: parseEntities(labels),
 		Text:  strings.Join(tokens, " "),
 	}
 }
 
+// makeEntity creates an Entity of type `label` from the given tokens, whose
+// text is taken from `text` if the tokens have offsets into it.
+func makeEntity(text string, tokens []*Token, label, value string) Entity {
+	chunk := makeChunk(text, tokens, label, 0)
+	return Entity{Span: chunk.Span, Text: chunk.Text, Label: label, Value: value}
+}
+
 func extract(i int, ctx []*Token, history []string) map[string]string {
 	feats := make(map[string]string)
 
@@ -0,0 +1,290 @@
+package prose
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// A ChunkGrammar is a cascade of chunking rules over POS tags, in the format
+// used by NLTK's RegexpParser:
+//
+//	NP: {<DT|PRP\$>?<JJ.*>*<NN.*>+}  # chunk determiner/adjectives/nouns
+//	    }<POS>{                      # chink possessive endings
+//	PP: {<IN><NP>}
+//
+// Each label starts a stage, whose rules are applied in order: "{...}" chunks
+// the tag sequences it matches outside of existing chunks, and "}...{" chinks
+// (removes) the sequences it matches inside of them, splitting the chunks if
+// necessary. Later stages see earlier stages' chunks as single elements
+// tagged with their labels.
+//
+// Within a tag pattern, "<...>" matches a single tag (where "." matches any
+// character but the angle brackets) and regular expression operators may be
+// used both inside and between tags. Text after an unescaped "#" is a
+// comment.
+type ChunkGrammar struct {
+	stages []chunkStage
+}
+
+type chunkStage struct {
+	label string
+	rules []chunkRule
+}
+
+type chunkRule struct {
+	chink   bool
+	pattern *regexp.Regexp
+}
+
+// chunkPiece is an element of a sentence as seen by a stage: either a token
+// or a chunk from an earlier stage.
+type chunkPiece struct {
+	tag    string
+	tokens []*Token
+}
+
+var (
+	chunkLabelRe  = regexp.MustCompile(`^([^\s{}<>:#]+)\s*:\s*(.*)$`)
+	chunkRepeatRe = regexp.MustCompile(`\{\d*,?\d*\}`)
+)
+
+// NewChunkGrammar compiles `grammar` (see ChunkGrammar).
+func NewChunkGrammar(grammar string) (*ChunkGrammar, error) {
+	g := &ChunkGrammar{}
+	for n, line := range strings.Split(grammar, "\n") {
+		line = strings.TrimSpace(stripGrammarComment(line))
+		if line == "" {
+			continue
+		}
+
+		if m := chunkLabelRe.FindStringSubmatch(line); m != nil {
+			g.stages = append(g.stages, chunkStage{label: m[1]})
+			if line = m[2]; line == "" {
+				continue
+			}
+		} else if len(g.stages) == 0 {
+			return nil, fmt.Errorf("prose: chunk grammar line %d: rule without a label", n+1)
+		}
+
+		rule, err := parseChunkRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("prose: chunk grammar line %d: %v", n+1, err)
+		}
+		stage := &g.stages[len(g.stages)-1]
+		stage.rules = append(stage.rules, rule)
+	}
+
+	if len(g.stages) == 0 {
+		return nil, errors.New("prose: chunk grammar has no rules")
+	}
+	for _, stage := range g.stages {
+		if len(stage.rules) == 0 {
+			return nil, errors.New("prose: chunk grammar label '" + stage.label + "' has no rules")
+		}
+	}
+
+	return g, nil
+}
+
+// Chunks returns the phrases that `grammar` finds in `doc`, sorted by
+// position (with enclosing chunks before the ones they contain). A chunk's
+// head is its final token.
+//
+// Chunking relies on POS tags, so it requires that tagging be enabled.
+// Chunks never cross sentence boundaries.
+func (doc *Document) Chunks(grammar *ChunkGrammar) []Chunk {
+	var chunks []Chunk
+	for _, group := range doc.tokenGroups() {
+		chunks = append(chunks, grammar.parse(doc.Text, group)...)
+	}
+	return chunks
+}
+
+// parse applies the grammar's stages to a single sentence.
+func (g *ChunkGrammar) parse(text string, tokens []*Token) []Chunk {
+	pieces := make([]chunkPiece, len(tokens))
+	for i, tok := range tokens {
+		pieces[i] = chunkPiece{tag: tok.Tag, tokens: tokens[i : i+1]}
+	}
+
+	var chunks []Chunk
+	for _, stage := range g.stages {
+		var found []Chunk
+		pieces, found = stage.apply(text, pieces)
+		chunks = append(chunks, found...)
+	}
+
+	sort.SliceStable(chunks, func(i, j int) bool {
+		if chunks[i].Start != chunks[j].Start {
+			return chunks[i].Start < chunks[j].Start
+		}
+		return chunks[i].End > chunks[j].End
+	})
+	return chunks
+}
+
+// apply runs a stage's rules over `pieces`, returning the pieces for the next
+// stage along with the chunks that were found.
+func (s chunkStage) apply(text string, pieces []chunkPiece) ([]chunkPiece, []Chunk) {
+	n := len(pieces)
+
+	// A chunk is a maximal run of pieces in a chunk, where only its first
+	// piece begins one.
+	in, begins := make([]bool, n), make([]bool, n)
+	for _, rule := range s.rules {
+		for i := 0; i < n; {
+			j := i + 1
+			for j < n && in[j] == in[i] && !(in[j] && begins[j]) {
+				j++
+			}
+
+			// Chunk rules apply between chunks; chink rules, within them.
+			if in[i] == rule.chink {
+				for _, span := range rule.find(pieces[i:j]) {
+					start, end := i+span[0], i+span[1]
+					for k := start; k < end; k++ {
+						in[k], begins[k] = !rule.chink, false
+					}
+					if !rule.chink {
+						begins[start] = true
+					} else if end < j {
+						begins[end] = true
+					}
+				}
+			}
+
+			i = j
+		}
+	}
+
+	var next []chunkPiece
+	var chunks []Chunk
+	for i := 0; i < n; {
+		if !in[i] {
+			next = append(next, pieces[i])
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < n && in[j] && !begins[j] {
+			j++
+		}
+
+		var tokens []*Token
+		for _, piece := range pieces[i:j] {
+			tokens = append(tokens, piece.tokens...)
+		}
+		chunks = append(chunks, makeChunk(text, tokens, s.label, len(tokens)-1))
+		next = append(next, chunkPiece{tag: s.label, tokens: tokens})
+
+		i = j
+	}
+
+	return next, chunks
+}
+
+// find returns the (non-empty) runs of `pieces` that match the rule's
+// pattern, as [start, end) indices.
+func (r chunkRule) find(pieces []chunkPiece) [][2]int {
+	var tags strings.Builder
+	bounds := make(map[int]int, len(pieces)+1) // byte offset -> piece index
+	for i, piece := range pieces {
+		bounds[tags.Len()] = i
+		tags.WriteString("<" + piece.tag + ">")
+	}
+	bounds[tags.Len()] = len(pieces)
+
+	var spans [][2]int
+	for _, loc := range r.pattern.FindAllStringIndex(tags.String(), -1) {
+		start, ok1 := bounds[loc[0]]
+		end, ok2 := bounds[loc[1]]
+		if ok1 && ok2 && end > start {
+			spans = append(spans, [2]int{start, end})
+		}
+	}
+	return spans
+}
+
+// parseChunkRule parses a single chunk ("{...}") or chink ("}...{") rule.
+func parseChunkRule(rule string) (chunkRule, error) {
+	n := len(rule)
+
+	var chink bool
+	switch {
+	case n > 2 && rule[0] == '{' && rule[n-1] == '}':
+		chink = false
+	case n > 2 && rule[0] == '}' && rule[n-1] == '{':
+		chink = true
+	default:
+		return chunkRule{}, fmt.Errorf("unsupported rule %q", rule)
+	}
+
+	inner := rule[1 : n-1]
+	if strings.ContainsAny(chunkRepeatRe.ReplaceAllString(inner, ""), "{}") {
+		return chunkRule{}, fmt.Errorf("unsupported rule %q", rule)
+	}
+
+	pattern, err := compileTagPattern(inner)
+	if err != nil {
+		return chunkRule{}, err
+	}
+	return chunkRule{chink: chink, pattern: pattern}, nil
+}
+
+// compileTagPattern converts a tag pattern (e.g., "<DT>?<NN.*>+") into a
+// regular expression over strings of tags such as "<DT><NN>".
+func compileTagPattern(pattern string) (*regexp.Regexp, error) {
+	var re strings.Builder
+
+	inTag, escaped := false, false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			re.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			re.WriteRune(r)
+			escaped = true
+		case unicode.IsSpace(r):
+		case r == '<':
+			if inTag {
+				return nil, fmt.Errorf("invalid tag pattern %q", pattern)
+			}
+			re.WriteString("(?:<(?:")
+			inTag = true
+		case r == '>':
+			if !inTag {
+				return nil, fmt.Errorf("invalid tag pattern %q", pattern)
+			}
+			re.WriteString(")>)")
+			inTag = false
+		case r == '.':
+			re.WriteString(`[^{}<>]`)
+		default:
+			re.WriteRune(r)
+		}
+	}
+
+	if inTag || escaped {
+		return nil, fmt.Errorf("invalid tag pattern %q", pattern)
+	}
+	return regexp.Compile(re.String())
+}
+
+// stripGrammarComment removes the comment, if any, from a line of a chunk
+// grammar.
+func stripGrammarComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+		} else if line[i] == '#' {
+			return line[:i]
+		}
+	}
+	return line
+}
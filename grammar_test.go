@@ -0,0 +1,94 @@
+package prose
+
+import (
+	"reflect"
+	"testing"
+)
+
+func chunkStrings(chunks []Chunk) []string {
+	observed := []string{}
+	for _, c := range chunks {
+		observed = append(observed, c.Text+"/"+c.Label)
+	}
+	return observed
+}
+
+func TestChunkGrammar(t *testing.T) {
+	doc := makeTagged(
+		"Rapunzel|NNP let|VBD down|RP her|PP$ long|JJ golden|JJ hair|NN .|. " +
+			"The|DT little|JJ yellow|JJ dog|NN barked|VBD at|IN the|DT cat|NN .|.")
+
+	grammar, err := NewChunkGrammar(`
+		NP: {<DT|PP\$>?<JJ>*<NN>}  # determiner/possessive, adjectives and noun
+		    {<NNP>+}               # sequences of proper nouns
+	`)
+	checkError(err)
+
+	expected := []string{
+		"Rapunzel/NP", "her long golden hair/NP", "The little yellow dog/NP", "the cat/NP"}
+	if observed := chunkStrings(doc.Chunks(grammar)); !reflect.DeepEqual(observed, expected) {
+		t.Errorf("Chunks() = %v, expected %v", observed, expected)
+	}
+}
+
+func TestChunkGrammarChinking(t *testing.T) {
+	doc := makeTagged(
+		"the|DT little|JJ yellow|JJ dog|NN barked|VBD at|IN the|DT cat|NN")
+
+	grammar, err := NewChunkGrammar(`
+		NP:
+		    {<.*>+}          # chunk everything
+		    }<VBD|IN>+{      # chink sequences of VBD and IN
+	`)
+	checkError(err)
+
+	chunks := doc.Chunks(grammar)
+	expected := []string{"the little yellow dog/NP", "the cat/NP"}
+	if observed := chunkStrings(chunks); !reflect.DeepEqual(observed, expected) {
+		t.Fatalf("Chunks() = %v, expected %v", observed, expected)
+	}
+	if head := chunks[0].Head.Text; head != "dog" {
+		t.Errorf("Head = %q, expected %q", head, "dog")
+	}
+}
+
+func TestChunkGrammarCascade(t *testing.T) {
+	doc := makeTagged(
+		"Mary|NN saw|VBD the|DT cat|NN sit|VB on|IN the|DT mat|NN")
+
+	grammar, err := NewChunkGrammar(`
+		NP: {<DT|JJ|NN.*>+}
+		PP: {<IN><NP>}
+		VP: {<VB.*><NP|PP|CLAUSE>+$}
+		CLAUSE: {<NP><VP>}
+	`)
+	checkError(err)
+
+	expected := []string{
+		"Mary/NP",
+		"the cat sit on the mat/CLAUSE",
+		"the cat/NP",
+		"sit on the mat/VP",
+		"on the mat/PP",
+		"the mat/NP",
+	}
+	if observed := chunkStrings(doc.Chunks(grammar)); !reflect.DeepEqual(observed, expected) {
+		t.Errorf("Chunks() = %v, expected %v", observed, expected)
+	}
+}
+
+func TestChunkGrammarErrors(t *testing.T) {
+	for _, grammar := range []string{
+		"",
+		"{<NN>}",
+		"NP:",
+		"NP: {<NN>",
+		"NP: {<DT>}{<NN>}",
+		"NP: {<NN<JJ>>}",
+		"NP: {<NN>[}",
+	} {
+		if _, err := NewChunkGrammar(grammar); err == nil {
+			t.Errorf("NewChunkGrammar(%q) succeeded, expected an error", grammar)
+		}
+	}
+}
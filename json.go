@@ -0,0 +1,221 @@
+package prose
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonVersion is the version of the JSON schema used to serialize Documents.
+//
+// It should be incremented whenever the schema changes in a way that older
+// versions of prose can't read.
+const jsonVersion = 1
+
+// jsonDocument is the serialized form of a Document.
+type jsonDocument struct {
+	Version   int            `json:"version"`
+	Model     string         `json:"model,omitempty"`
+	Text      string         `json:"text"`
+	Tokens    []jsonToken    `json:"tokens"`
+	Sentences []jsonSentence `json:"sentences"`
+	Entities  []jsonEntity   `json:"entities"`
+
+	Classification *jsonClassification `json:"classification,omitempty"`
+	Sentiment      *jsonSentiment      `json:"sentiment,omitempty"`
+}
+
+type jsonSpan struct {
+	Start     int `json:"start"`
+	End       int `json:"end"`
+	RuneStart int `json:"rune_start"`
+	RuneEnd   int `json:"rune_end"`
+}
+
+type jsonToken struct {
+	jsonSpan
+	Text  string `json:"text"`
+	Tag   string `json:"tag,omitempty"`
+	Label string `json:"label,omitempty"`
+	Lemma string `json:"lemma,omitempty"`
+	Stem  string `json:"stem,omitempty"`
+	Dep   string `json:"dep,omitempty"`
+
+	// Head is nil for tokens that haven't been parsed, since 0 is a valid
+	// head.
+	Head *int `json:"head"`
+}
+
+type jsonSentence struct {
+	jsonSpan
+	Text string `json:"text"`
+
+	Classification *jsonClassification `json:"classification,omitempty"`
+	Sentiment      *jsonSentiment      `json:"sentiment,omitempty"`
+}
+
+type jsonClassification struct {
+	Label string             `json:"label"`
+	Probs map[string]float64 `json:"probs"`
+}
+
+type jsonSentiment struct {
+	Positive float64 `json:"pos"`
+	Negative float64 `json:"neg"`
+	Neutral  float64 `json:"neu"`
+	Compound float64 `json:"compound"`
+}
+
+type jsonEntity struct {
+	jsonSpan
+	Text  string `json:"text"`
+	Label string `json:"label"`
+	Value string `json:"value,omitempty"`
+}
+
+func toJSONSpan(s Span) jsonSpan {
+	return jsonSpan{Start: s.Start, End: s.End, RuneStart: s.RuneStart, RuneEnd: s.RuneEnd}
+}
+
+func fromJSONSpan(s jsonSpan) Span {
+	return Span{Start: s.Start, End: s.End, RuneStart: s.RuneStart, RuneEnd: s.RuneEnd}
+}
+
+// toJSONClassification omits empty (i.e., missing) classifications.
+func toJSONClassification(c Classification) *jsonClassification {
+	if c.Label == "" {
+		return nil
+	}
+	return &jsonClassification{Label: c.Label, Probs: c.Probs}
+}
+
+func fromJSONClassification(c *jsonClassification) Classification {
+	if c == nil {
+		return Classification{}
+	}
+	return Classification{Label: c.Label, Probs: c.Probs}
+}
+
+// toJSONSentiment omits empty (i.e., missing) sentiment scores.
+func toJSONSentiment(s Sentiment) *jsonSentiment {
+	if s == (Sentiment{}) {
+		return nil
+	}
+	return &jsonSentiment{
+		Positive: s.Positive, Negative: s.Negative, Neutral: s.Neutral, Compound: s.Compound}
+}
+
+func fromJSONSentiment(s *jsonSentiment) Sentiment {
+	if s == nil {
+		return Sentiment{}
+	}
+	return Sentiment{
+		Positive: s.Positive, Negative: s.Negative, Neutral: s.Neutral, Compound: s.Compound}
+}
+
+// MarshalJSON encodes `doc`, including its tokens, sentences and entities,
+// as JSON.
+//
+// The Model itself isn't included, only its name.
+func (doc *Document) MarshalJSON() ([]byte, error) {
+	out := jsonDocument{
+		Version:   jsonVersion,
+		Text:      doc.Text,
+		Tokens:    make([]jsonToken, len(doc.tokens)),
+		Sentences: make([]jsonSentence, len(doc.sentences)),
+		Entities:  make([]jsonEntity, len(doc.entities)),
+	}
+	if doc.Model != nil {
+		out.Model = doc.Model.Name
+	}
+	out.Classification = toJSONClassification(doc.classification)
+	out.Sentiment = toJSONSentiment(doc.sentiment)
+
+	for i, tok := range doc.tokens {
+		out.Tokens[i] = jsonToken{
+			jsonSpan: toJSONSpan(tok.Span),
+			Text:     tok.Text,
+			Tag:      tok.Tag,
+			Label:    tok.Label,
+			Lemma:    tok.Lemma,
+			Stem:     tok.Stem,
+			Dep:      tok.Dep}
+		if tok.Parsed() {
+			head := tok.Head
+			out.Tokens[i].Head = &head
+		}
+	}
+	for i, sent := range doc.sentences {
+		out.Sentences[i] = jsonSentence{
+			jsonSpan:       toJSONSpan(sent.Span),
+			Text:           sent.Text,
+			Classification: toJSONClassification(sent.classification),
+			Sentiment:      toJSONSentiment(sent.sentiment)}
+	}
+	for i, ent := range doc.entities {
+		out.Entities[i] = jsonEntity{
+			jsonSpan: toJSONSpan(ent.Span),
+			Text:     ent.Text,
+			Label:    ent.Label,
+			Value:    ent.Value}
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON rebuilds a Document from the output of MarshalJSON, without
+// re-processing its text.
+//
+// Since Models aren't serialized, the resulting Document's Model is nil.
+func (doc *Document) UnmarshalJSON(data []byte) error {
+	var in jsonDocument
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if in.Version > jsonVersion {
+		return fmt.Errorf("prose: unsupported document version %d", in.Version)
+	}
+
+	*doc = Document{Text: in.Text}
+
+	var tokens []*Token
+	for _, tok := range in.Tokens {
+		token := &Token{
+			Span:  fromJSONSpan(tok.jsonSpan),
+			Text:  tok.Text,
+			Tag:   tok.Tag,
+			Label: tok.Label,
+			Lemma: tok.Lemma,
+			Stem:  tok.Stem,
+			Dep:   tok.Dep}
+		if tok.Head != nil {
+			token.Head = *tok.Head
+		}
+		tokens = append(tokens, token)
+	}
+
+	var sents []Sentence
+	for _, sent := range in.Sentences {
+		sents = append(sents, Sentence{
+			Span:           fromJSONSpan(sent.jsonSpan),
+			Text:           sent.Text,
+			classification: fromJSONClassification(sent.Classification),
+			sentiment:      fromJSONSentiment(sent.Sentiment)})
+	}
+
+	var entities []Entity
+	for _, ent := range in.Entities {
+		entities = append(entities, Entity{
+			Span:  fromJSONSpan(ent.jsonSpan),
+			Text:  ent.Text,
+			Label: ent.Label,
+			Value: ent.Value})
+	}
+
+	doc.SetTokens(tokens)
+	doc.SetEntities(entities)
+	doc.SetSentences(sents)
+	doc.classification = fromJSONClassification(in.Classification)
+	doc.sentiment = fromJSONSentiment(in.Sentiment)
+
+	return nil
+}
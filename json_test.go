@@ -0,0 +1,88 @@
+package prose
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDocumentJSON(t *testing.T) {
+	doc, err := NewDocument(
+		"Windows 10 is an operating system. Hello there. I don’t prefer Windows 10 to macOS. See you tomorrow.",
+		UsingModel(ModelFromDisk(filepath.Join(testdata, "PRODUCT"))),
+		WithTemporalEntities(time.Date(2024, time.June, 12, 0, 0, 0, 0, time.UTC)),
+		WithLemmatization(true),
+		WithStemming(true))
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("MarshalJSON() unexpected error: %v", err)
+	}
+
+	var decoded Document
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalJSON() unexpected error: %v", err)
+	}
+
+	if decoded.Text != doc.Text {
+		t.Errorf("UnmarshalJSON() expected text = %v, got = %v", doc.Text, decoded.Text)
+	}
+	if !reflect.DeepEqual(decoded.Tokens(), doc.Tokens()) {
+		t.Errorf("UnmarshalJSON() expected tokens = %v, got = %v",
+			doc.Tokens(), decoded.Tokens())
+	}
+	if !reflect.DeepEqual(decoded.Sentences(), doc.Sentences()) {
+		t.Errorf("UnmarshalJSON() expected sentences = %v, got = %v",
+			doc.Sentences(), decoded.Sentences())
+	}
+	if !reflect.DeepEqual(decoded.Entities(), doc.Entities()) {
+		t.Errorf("UnmarshalJSON() expected entities = %v, got = %v",
+			doc.Entities(), decoded.Entities())
+	}
+
+	if err = json.Unmarshal([]byte(`{"version": 99}`), &decoded); err == nil {
+		t.Errorf("UnmarshalJSON() expected an error for an unknown version")
+	}
+}
+
+func TestDocumentJSONHeads(t *testing.T) {
+	doc := makeTagged("Cats|NNS eat|VBP fish|NN")
+	doc.tokens[0].Head, doc.tokens[0].Dep = 1, "nsubj"
+	doc.tokens[1].Head, doc.tokens[1].Dep = -1, "root"
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("MarshalJSON() unexpected error: %v", err)
+	}
+
+	var decoded Document
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalJSON() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Tokens(), doc.Tokens()) {
+		t.Errorf("UnmarshalJSON() expected tokens = %v, got = %v",
+			doc.Tokens(), decoded.Tokens())
+	}
+
+	// A head of 0 is kept, while an unparsed token has no head.
+	doc.tokens[1].Head, doc.tokens[1].Dep = 0, "obj"
+	data, err = json.Marshal(doc)
+	checkError(err)
+
+	var raw struct {
+		Tokens []map[string]interface{} `json:"tokens"`
+	}
+	checkError(json.Unmarshal(data, &raw))
+	heads := []interface{}{}
+	for _, tok := range raw.Tokens {
+		heads = append(heads, tok["head"])
+	}
+	if expected := []interface{}{1.0, 0.0, nil}; !reflect.DeepEqual(heads, expected) {
+		t.Errorf("MarshalJSON() expected heads = %v, got = %v", expected, heads)
+	}
+}
@@ -0,0 +1,304 @@
+package prose
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// A Keyphrase is a phrase that characterizes a Document (see
+// Document.Keyphrases).
+type Keyphrase struct {
+	Text  string  // The phrase's text (as it first appears).
+	Score float64 // The phrase's score; higher is more relevant.
+	Spans []Span  // The location of each of the phrase's occurrences.
+}
+
+// KeyphraseMethod is an algorithm for ranking keyphrases.
+type KeyphraseMethod int
+
+const (
+	// TextRank ranks words by their centrality in a graph of co-occurring
+	// words (Mihalcea and Tarau, 2004).
+	TextRank KeyphraseMethod = iota
+	// RAKE ranks words by the ratio of their degree to their frequency within
+	// the candidate phrases (Rose et al., 2010).
+	RAKE
+)
+
+// KeyphraseOpts controls keyphrase extraction:
+type KeyphraseOpts struct {
+	Method    KeyphraseMethod // The ranking algorithm (default: TextRank)
+	Stopwords []string        // Words that never appear in keyphrases
+	MaxLength int             // The maximum number of words in a keyphrase
+}
+
+// A KeyphraseOpt represents a setting that changes keyphrase extraction.
+type KeyphraseOpt func(opts *KeyphraseOpts)
+
+// UsingKeyphraseMethod specifies the algorithm used to rank keyphrases.
+func UsingKeyphraseMethod(method KeyphraseMethod) KeyphraseOpt {
+	return func(opts *KeyphraseOpts) {
+		opts.Method = method
+	}
+}
+
+// UsingStopwords replaces the default stopwords (see EnglishStopwords).
+func UsingStopwords(words []string) KeyphraseOpt {
+	return func(opts *KeyphraseOpts) {
+		opts.Stopwords = words
+	}
+}
+
+// WithMaxPhraseLength limits keyphrases to `n` words (3 by default).
+func WithMaxPhraseLength(n int) KeyphraseOpt {
+	return func(opts *KeyphraseOpts) {
+		opts.MaxLength = n
+	}
+}
+
+// Keyphrases returns `doc`'s keyphrases, from the most to least relevant.
+//
+// Candidate phrases are sequences of adjectives followed by nouns ("neural
+// machine translation") that don't contain any stopwords, so extraction
+// relies on POS tags and requires that tagging be enabled. Candidates longer
+// than the maximum length are trimmed to their final words (i.e., those
+// closest to the head noun).
+func (doc *Document) Keyphrases(opts ...KeyphraseOpt) []Keyphrase {
+	base := KeyphraseOpts{
+		Method:    TextRank,
+		Stopwords: englishStopwords,
+		MaxLength: 3,
+	}
+	for _, applyOpt := range opts {
+		applyOpt(&base)
+	}
+
+	stopwords := stopwordSet(base.Stopwords)
+	groups := [][]keyphraseCandidate{}
+	for _, group := range doc.tokenGroups() {
+		groups = append(groups, keyphraseCandidates(group, stopwords, base.MaxLength))
+	}
+
+	var scores map[string]float64
+	if base.Method == RAKE {
+		scores = rakeScores(groups)
+	} else {
+		scores = textRankScores(groups)
+	}
+
+	return rankKeyphrases(doc.Text, groups, scores)
+}
+
+// keyphraseCandidate is a single occurrence of a candidate phrase.
+type keyphraseCandidate struct {
+	tokens []*Token
+	words  []string // The lowercased text of each token.
+}
+
+// keyphraseCandidates finds the candidate phrases in a single sentence:
+//
+//	<JJ|JJR|JJS>* <NN|NNS|NNP|NNPS>+
+//
+// where none of the words are stopwords.
+func keyphraseCandidates(tokens []*Token, stopwords map[string]bool, maxLength int) []keyphraseCandidate {
+	var candidates []keyphraseCandidate
+
+	usable := func(tok *Token, tagged func(string) bool) bool {
+		return tagged(tok.Tag) && !stopwords[strings.ToLower(tok.Text)] && hasLetter(tok.Text)
+	}
+
+	for i := 0; i < len(tokens); {
+		j := i
+		for j < len(tokens) && usable(tokens[j], isAdjective) {
+			j++
+		}
+
+		nouns := j
+		for j < len(tokens) && usable(tokens[j], isNoun) {
+			j++
+		}
+
+		if j == nouns {
+			// No nouns: skip past the adjectives (or the current token).
+			if j == i {
+				j++
+			}
+			i = j
+			continue
+		}
+
+		start := i
+		if maxLength > 0 && j-start > maxLength {
+			start = j - maxLength
+		}
+
+		candidate := keyphraseCandidate{tokens: tokens[start:j]}
+		for _, tok := range candidate.tokens {
+			candidate.words = append(candidate.words, strings.ToLower(tok.Text))
+		}
+		candidates = append(candidates, candidate)
+
+		i = j
+	}
+
+	return candidates
+}
+
+// rakeScores scores each word by deg(w) / freq(w), where deg(w) is the total
+// length of the candidates in which `w` occurs.
+func rakeScores(groups [][]keyphraseCandidate) map[string]float64 {
+	degree := make(map[string]float64)
+	freq := make(map[string]float64)
+	for _, group := range groups {
+		for _, candidate := range group {
+			for _, word := range candidate.words {
+				degree[word] += float64(len(candidate.words))
+				freq[word]++
+			}
+		}
+	}
+
+	scores := make(map[string]float64)
+	for word, f := range freq {
+		scores[word] = degree[word] / f
+	}
+	return scores
+}
+
+const (
+	// textRankDamping is PageRank's damping factor.
+	textRankDamping = 0.85
+	// textRankIterations bounds the number of PageRank iterations.
+	textRankIterations = 100
+	// textRankTolerance is the change in scores below which PageRank has
+	// converged.
+	textRankTolerance = 1e-6
+)
+
+// textRankScores scores each word by running PageRank over an undirected
+// graph whose edges connect words that are adjacent within a sentence (once
+// non-candidate words have been removed).
+func textRankScores(groups [][]keyphraseCandidate) map[string]float64 {
+	index := make(map[string]int)
+	edges := []map[int]float64{}
+	vertex := func(word string) int {
+		if idx, found := index[word]; found {
+			return idx
+		}
+		index[word] = len(edges)
+		edges = append(edges, make(map[int]float64))
+		return index[word]
+	}
+
+	for _, group := range groups {
+		prev := -1
+		for _, candidate := range group {
+			for _, word := range candidate.words {
+				v := vertex(word)
+				if prev >= 0 && prev != v {
+					edges[prev][v]++
+					edges[v][prev]++
+				}
+				prev = v
+			}
+		}
+	}
+
+	ranks := pageRank(edges, nil)
+	scores := make(map[string]float64, len(index))
+	for word, idx := range index {
+		scores[word] = ranks[idx]
+	}
+	return scores
+}
+
+// pageRank computes the weighted PageRank of each vertex of a graph, where
+// `edges[u][v]` is the weight of the edge from `u` to `v`.
+//
+// If `teleport` is non-nil, it's used instead of a uniform distribution for
+// random jumps (i.e., "personalized" PageRank); it should sum to 1.
+func pageRank(edges []map[int]float64, teleport []float64) []float64 {
+	n := len(edges)
+
+	// We visit neighbors in a fixed order so that the (floating-point)
+	// results are deterministic.
+	neighbors := make([][]int, n)
+	outWeight := make([]float64, n)
+	scores := make([]float64, n)
+	for u := range edges {
+		for v, w := range edges[u] {
+			neighbors[u] = append(neighbors[u], v)
+			outWeight[u] += w
+		}
+		sort.Ints(neighbors[u])
+		scores[u] = 1
+	}
+
+	jump := func(v int) float64 {
+		if teleport == nil {
+			return 1 - textRankDamping
+		}
+		return (1 - textRankDamping) * teleport[v] * float64(n)
+	}
+
+	for iter := 0; iter < textRankIterations; iter++ {
+		change := 0.0
+		updated := make([]float64, n)
+		for u := range edges {
+			for _, v := range neighbors[u] {
+				updated[v] += textRankDamping * edges[u][v] / outWeight[u] * scores[u]
+			}
+		}
+		for v := range updated {
+			updated[v] += jump(v)
+			change = math.Max(change, math.Abs(updated[v]-scores[v]))
+		}
+		scores = updated
+		if change < textRankTolerance {
+			break
+		}
+	}
+
+	return scores
+}
+
+// rankKeyphrases groups the occurrences of each candidate, scores it by the
+// sum of its words' scores, and sorts the results.
+func rankKeyphrases(text string, groups [][]keyphraseCandidate, scores map[string]float64) []Keyphrase {
+	var phrases []Keyphrase
+	index := make(map[string]int)
+
+	for _, group := range groups {
+		for _, candidate := range group {
+			chunk := makeChunk(text, candidate.tokens, "", 0)
+			key := strings.Join(candidate.words, " ")
+			if idx, found := index[key]; found {
+				phrases[idx].Spans = append(phrases[idx].Spans, chunk.Span)
+				continue
+			}
+
+			score := 0.0
+			for _, word := range candidate.words {
+				score += scores[word]
+			}
+
+			index[key] = len(phrases)
+			phrases = append(phrases, Keyphrase{
+				Text:  chunk.Text,
+				Score: score,
+				Spans: []Span{chunk.Span}})
+		}
+	}
+
+	// A stable sort keeps ties in order of their first occurrence.
+	sort.SliceStable(phrases, func(i, j int) bool {
+		return phrases[i].Score > phrases[j].Score
+	})
+
+	return phrases
+}
+
+func isAdjective(tag string) bool {
+	return tag == "JJ" || tag == "JJR" || tag == "JJS"
+}
@@ -0,0 +1,88 @@
+package prose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The abstract used as an example by Mihalcea and Tarau (2004).
+const keyphraseAbstract = "Compatibility|NN of|IN systems|NNS of|IN linear|JJ " +
+	"constraints|NNS over|IN the|DT set|NN of|IN natural|JJ numbers|NNS .|. " +
+	"Criteria|NNS of|IN compatibility|NN of|IN a|DT system|NN of|IN " +
+	"linear|JJ Diophantine|NNP equations|NNS ,|, strict|JJ inequations|NNS ,|, " +
+	"and|CC nonstrict|JJ inequations|NNS are|VBP considered|VBN .|. " +
+	"Upper|JJ bounds|NNS for|IN components|NNS of|IN a|DT minimal|JJ set|NN " +
+	"of|IN solutions|NNS and|CC algorithms|NNS of|IN construction|NN of|IN " +
+	"minimal|JJ generating|VBG sets|NNS of|IN solutions|NNS for|IN all|DT " +
+	"types|NNS of|IN systems|NNS are|VBP given|VBN .|."
+
+func keyphraseTexts(phrases []Keyphrase, n int) []string {
+	texts := []string{}
+	for i := 0; i < n && i < len(phrases); i++ {
+		texts = append(texts, phrases[i].Text)
+	}
+	return texts
+}
+
+func TestKeyphrasesTextRank(t *testing.T) {
+	doc := makeTagged(keyphraseAbstract)
+
+	phrases := doc.Keyphrases()
+	top := keyphraseTexts(phrases, 3)
+	expected := []string{"linear Diophantine equations", "minimal set", "linear constraints"}
+	if !reflect.DeepEqual(top, expected) {
+		t.Errorf("Keyphrases() expected = %v, got = %v", expected, top)
+	}
+
+	for i := 1; i < len(phrases); i++ {
+		if phrases[i].Score > phrases[i-1].Score {
+			t.Errorf("Keyphrases() not sorted: %v", phrases)
+		}
+	}
+
+	for _, phrase := range phrases {
+		for _, span := range phrase.Spans {
+			if !strings.EqualFold(doc.Text[span.Start:span.End], phrase.Text) {
+				t.Errorf("Keyphrases() bad span for %q: %v", phrase.Text, span)
+			}
+		}
+		if phrase.Text == "solutions" && len(phrase.Spans) != 2 {
+			t.Errorf("Keyphrases() expected 2 occurrences of %q, got = %v",
+				phrase.Text, phrase.Spans)
+		}
+	}
+}
+
+func TestKeyphrasesRAKE(t *testing.T) {
+	doc := makeTagged(keyphraseAbstract)
+
+	top := keyphraseTexts(doc.Keyphrases(UsingKeyphraseMethod(RAKE)), 2)
+	expected := []string{"linear Diophantine equations", "linear constraints"}
+	if !reflect.DeepEqual(top, expected) {
+		t.Errorf("Keyphrases(RAKE) expected = %v, got = %v", expected, top)
+	}
+}
+
+func TestKeyphraseOpts(t *testing.T) {
+	doc := makeTagged(keyphraseAbstract)
+
+	trimmed := false
+	for _, phrase := range doc.Keyphrases(WithMaxPhraseLength(2)) {
+		if n := len(strings.Fields(phrase.Text)); n > 2 {
+			t.Errorf("Keyphrases() expected at most 2 words, got = %q", phrase.Text)
+		}
+		trimmed = trimmed || phrase.Text == "Diophantine equations"
+	}
+	if !trimmed {
+		t.Errorf("Keyphrases() expected long candidates to keep their final words")
+	}
+
+	stopwords := append(EnglishStopwords(), "linear", "minimal")
+	for _, phrase := range doc.Keyphrases(UsingStopwords(stopwords)) {
+		words := strings.ToLower(phrase.Text)
+		if strings.Contains(words, "linear") || strings.Contains(words, "minimal") {
+			t.Errorf("Keyphrases() unexpected stopword in %q", phrase.Text)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package prose
+
+import "strings"
+
+// LemmatizerComponent returns the built-in lemmatization stage, which fills
+// in each token's Lemma based on its text and POS tag.
+//
+// It expects its input to have already been POS tagged.
+func LemmatizerComponent() Component {
+	return lemmatizerComponent{}
+}
+
+type lemmatizerComponent struct{}
+
+func (lemmatizerComponent) Name() string { return LemmatizerName }
+
+func (lemmatizerComponent) Process(doc *Document) error {
+	for _, tok := range doc.tokens {
+		tok.Lemma = Lemmatize(tok.Text, tok.Tag)
+	}
+	return nil
+}
+
+// Lemmatize returns the lemma (dictionary form) of `word`, given its Penn
+// Treebank POS tag.
+//
+// For example, "was"/VBD -> "be", "mice"/NNS -> "mouse" and "better"/JJR ->
+// "good". Irregular forms are looked up in a built-in exception lexicon,
+// while regular forms are handled by suffix-stripping rules. Lemmas are
+// lowercase, except for those of proper nouns.
+func Lemmatize(word, tag string) string {
+	lower := strings.ToLower(word)
+	if lemma, found := lemmaExceptions[tag][lower]; found {
+		return lemma
+	}
+
+	switch tag {
+	case "NNP":
+		return word
+	case "NNPS":
+		// We keep the original casing, so we only handle plurals that simply
+		// add a suffix (e.g., "Americans").
+		if s := singularize(lower); len(word) == len(lower) && strings.HasPrefix(lower, s) {
+			return word[:len(s)]
+		}
+		return word
+	case "NNS":
+		return singularize(lower)
+	case "VBZ":
+		return singularize(lower)
+	case "VBD", "VBN":
+		return stripVerbSuffix(lower, "ed")
+	case "VBG":
+		return stripVerbSuffix(lower, "ing")
+	case "JJR", "RBR":
+		return stripComparative(lower, "er")
+	case "JJS", "RBS":
+		return stripComparative(lower, "est")
+	case "PRP":
+		if lower == "i" {
+			return "I"
+		}
+	}
+
+	return lower
+}
+
+// singularize removes the plural (or third-person singular) suffix from
+// `word`.
+func singularize(word string) string {
+	n := len(word)
+	switch {
+	case strings.HasSuffix(word, "men") && n > 4:
+		// "firemen" -> "fireman"
+		return word[:n-3] + "man"
+	case n < 3 || !strings.HasSuffix(word, "s"):
+		return word
+	case strings.HasSuffix(word, "ss") || strings.HasSuffix(word, "us") ||
+		strings.HasSuffix(word, "is"):
+		// "glass", "status", "analysis"
+		return word
+	case strings.HasSuffix(word, "ies") && n > 4:
+		// "cities" -> "city"
+		return word[:n-3] + "y"
+	case strings.HasSuffix(word, "ses") && sStems[word[:n-2]]:
+		// "focuses" -> "focus", but "causes" -> "cause"
+		return word[:n-2]
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "xes"),
+		strings.HasSuffix(word, "zzes"):
+		// "classes" -> "class", "boxes" -> "box"
+		return word[:n-2]
+	case strings.HasSuffix(word, "oes") && n > 4:
+		// "heroes" -> "hero"
+		return word[:n-2]
+	}
+	return word[:n-1]
+}
+
+// stripVerbSuffix removes an inflectional suffix ("ed" or "ing") from `word`,
+// restoring any letters that were dropped or doubled when it was added.
+func stripVerbSuffix(word, suffix string) string {
+	if !strings.HasSuffix(word, suffix) || len(word) < len(suffix)+2 {
+		return word
+	}
+
+	stem := word[:len(word)-len(suffix)]
+	switch {
+	case suffix == "ed" && len(word) == 4 && strings.HasSuffix(word, "ied"):
+		// "died" -> "die", "tied" -> "tie"
+		return stem + "e"
+	case suffix == "ing" && len(word) == 5 && strings.HasSuffix(word, "ying"):
+		// "dying" -> "die", "lying" -> "lie"
+		return word[:1] + "ie"
+	case suffix == "ed" && strings.HasSuffix(word, "ied"):
+		// "tried" -> "try"
+		return stem[:len(stem)-1] + "y"
+	case suffix == "ed" && strings.HasSuffix(word, "eed"):
+		// "agreed" -> "agree"
+		return word[:len(word)-1]
+	case strings.HasSuffix(stem, "e"):
+		// "seeing" -> "see"
+		return stem
+	}
+	return restoreStem(stem)
+}
+
+// stripComparative removes a comparative ("er") or superlative ("est")
+// suffix from `word`.
+func stripComparative(word, suffix string) string {
+	if !strings.HasSuffix(word, suffix) || len(word) < len(suffix)+2 {
+		return word
+	}
+
+	stem := word[:len(word)-len(suffix)]
+	if strings.HasSuffix(stem, "i") {
+		// "happier" -> "happy"
+		return stem[:len(stem)-1] + "y"
+	} else if strings.HasSuffix(stem, "u") {
+		// "bluer" -> "blue"
+		return stem + "e"
+	}
+	return restoreStem(stem)
+}
+
+// restoreStem undoes the spelling changes that English makes when adding a
+// vowel-initial suffix to a stem: consonant doubling ("running" -> "run")
+// and silent-e deletion ("making" -> "make").
+func restoreStem(stem string) string {
+	n := len(stem)
+	if n < 2 {
+		return stem
+	}
+
+	last, prev := stem[n-1], stem[n-2]
+	if last == prev && !isVowel(last) {
+		if strings.ContainsRune("lsfz", rune(last)) {
+			// "falling" -> "fall", "missed" -> "miss", but
+			// "controlled" -> "control"
+			if last == 'l' && vowelGroups(stem) > 1 && !isVowel(stem[0]) {
+				return stem[:n-1]
+			}
+			return stem
+		}
+		// "running" -> "run", "stopped" -> "stop"
+		return stem[:n-1]
+	}
+
+	switch {
+	case strings.HasSuffix(stem, "v"), strings.HasSuffix(stem, "c"),
+		strings.HasSuffix(stem, "dg"), strings.HasSuffix(stem, "rg"),
+		strings.HasSuffix(stem, "lg"), strings.HasSuffix(stem, "iz"),
+		strings.HasSuffix(stem, "yz"), strings.HasSuffix(stem, "uir"):
+		// "loving" -> "love", "producing" -> "produce", "judged" -> "judge",
+		// "realizing" -> "realize", "required" -> "require"
+		return stem + "e"
+	case n > 2 && last == 'l' && strings.ContainsRune("bcdfgkptz", rune(prev)):
+		// "handling" -> "handle", "settled" -> "settle"
+		return stem + "e"
+	case n > 3 && strings.HasSuffix(stem, "at") && !isVowel(stem[n-3]),
+		strings.HasSuffix(stem, "iat"), strings.HasSuffix(stem, "uat"),
+		strings.HasSuffix(stem, "creat"):
+		// "relating" -> "relate", "negotiated" -> "negotiate",
+		// "created" -> "create" (but "heated" -> "heat")
+		return stem + "e"
+	case vowelGroups(stem) == 1 && isShortSyllable(stem):
+		// "hoping" -> "hope", "making" -> "make", "using" -> "use"
+		return stem + "e"
+	}
+
+	return stem
+}
+
+// isShortSyllable determines if `stem` ends in a single vowel followed by a
+// single consonant other than "w", "x" or "y".
+func isShortSyllable(stem string) bool {
+	n := len(stem)
+	last, prev := stem[n-1], stem[n-2]
+	if isVowel(last) || !isVowel(prev) || strings.ContainsRune("wxy", rune(last)) {
+		return false
+	}
+	return n == 2 || !isVowel(stem[n-3])
+}
+
+// vowelGroups counts the runs of vowels in `word`.
+func vowelGroups(word string) int {
+	groups, inGroup := 0, false
+	for i := 0; i < len(word); i++ {
+		vowel := isVowel(word[i])
+		if vowel && !inGroup {
+			groups++
+		}
+		inGroup = vowel
+	}
+	return groups
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// irregularVerbs maps the inflected forms of irregular verbs to their base
+// forms.
+var irregularVerbs = map[string]string{
+	"'m": "be", "'re": "be", "'s": "be", "am": "be", "are": "be", "is": "be",
+	"was": "be", "were": "be", "been": "be", "being": "be",
+	"'ve": "have", "has": "have", "had": "have", "'d": "have",
+	"does": "do", "did": "do", "done": "do",
+	"goes": "go", "went": "go", "gone": "go",
+	"ate": "eat", "eaten": "eat",
+	"became": "become", "began": "begin", "begun": "begin", "bent": "bend",
+	"bit": "bite", "bitten": "bite", "bled": "bleed", "blew": "blow",
+	"blown": "blow", "bore": "bear", "born": "bear", "borne": "bear",
+	"bought": "buy", "bound": "bind", "broke": "break", "broken": "break",
+	"brought": "bring", "built": "build", "came": "come", "caught": "catch",
+	"chose": "choose", "chosen": "choose", "dealt": "deal", "drank": "drink",
+	"drew": "draw", "drawn": "draw", "drove": "drive", "driven": "drive",
+	"drunk": "drink", "dug": "dig", "fed": "feed", "fell": "fall",
+	"fallen": "fall", "felt": "feel", "fled": "flee",
+	"flew": "fly", "flown": "fly", "fought": "fight", "found": "find",
+	"forbade": "forbid", "forbidden": "forbid", "forgave": "forgive",
+	"forgiven": "forgive", "forgot": "forget", "forgotten": "forget",
+	"froze": "freeze", "frozen": "freeze", "gave": "give", "given": "give",
+	"got": "get", "gotten": "get", "grew": "grow", "grown": "grow",
+	"held": "hold", "heard": "hear", "hid": "hide", "hidden": "hide",
+	"hung": "hang", "kept": "keep", "knew": "know", "known": "know",
+	"laid": "lay", "lain": "lie", "led": "lead", "left": "leave",
+	"lent": "lend", "lit": "light", "lost": "lose", "made": "make",
+	"meant": "mean", "met": "meet",
+	"paid": "pay", "ran": "run", "rang": "ring", "rode": "ride",
+	"ridden": "ride", "rose": "rise", "risen": "rise", "rung": "ring",
+	"said": "say", "sang": "sing", "sat": "sit", "saw": "see", "seen": "see",
+	"sent": "send", "shook": "shake", "shaken": "shake", "shone": "shine",
+	"shot": "shoot", "slept": "sleep", "slid": "slide", "sold": "sell",
+	"sought": "seek", "spat": "spit", "spent": "spend", "spoke": "speak",
+	"spoken": "speak", "spun": "spin", "stole": "steal", "stolen": "steal",
+	"stood": "stand", "struck": "strike", "stuck": "stick", "sung": "sing",
+	"swam": "swim", "swept": "sweep", "swore": "swear", "sworn": "swear",
+	"swum": "swim", "taught": "teach", "thought": "think", "threw": "throw",
+	"thrown": "throw", "told": "tell", "took": "take", "taken": "take",
+	"tore": "tear", "torn": "tear",
+	"understood": "understand", "woke": "wake", "woken": "wake",
+	"won": "win", "wore": "wear", "worn": "wear", "wept": "weep",
+	"wound": "wind", "wrote": "write", "written": "write",
+}
+
+// irregularNouns maps irregular plural nouns to their singular forms.
+var irregularNouns = map[string]string{
+	"analyses": "analysis", "axes": "axis", "bases": "basis",
+	"cacti": "cactus", "children": "child", "crises": "crisis",
+	"criteria": "criterion", "data": "datum", "diagnoses": "diagnosis",
+	"dice": "die", "elves": "elf", "feet": "foot", "fungi": "fungus",
+	"geese": "goose", "halves": "half", "hypotheses": "hypothesis",
+	"indices": "index", "knives": "knife", "leaves": "leaf", "lives": "life",
+	"loaves": "loaf", "matrices": "matrix", "mice": "mouse", "men": "man",
+	"nuclei": "nucleus", "oxen": "ox", "parentheses": "parenthesis",
+	"people": "person", "phenomena": "phenomenon", "radii": "radius",
+	"selves": "self", "series": "series", "sheep": "sheep",
+	"shelves": "shelf", "shoes": "shoe", "species": "species",
+	"stimuli": "stimulus", "syllabi": "syllabus", "teeth": "tooth",
+	"theses": "thesis", "thieves": "thief", "toes": "toe", "vertices": "vertex",
+	"wives": "wife", "wolves": "wolf", "women": "woman", "quizzes": "quiz",
+	"canoes": "canoe", "ties": "tie", "lies": "lie", "pies": "pie",
+}
+
+// sStems lists words that end in a single "s" and so add "-es" (rather than
+// "-s") in the plural or third person: most words that end in "-ses" simply
+// add an "s" to an "-se" ("causes", "uses").
+var sStems = stopwordSet(strings.Fields(`
+	abacus alias apparatus atlas bias bonus bus cactus campus canvas caucus
+	census chorus circus consensus corpus fetus focus gas genius iris lens
+	minus octopus pancreas plus prospectus rebus sinus status surplus
+	syllabus virus walrus
+`))
+
+// irregularAdjectives maps irregular comparative and superlative adjectives
+// to their base forms.
+var irregularAdjectives = map[string]string{
+	"better": "good", "best": "good", "worse": "bad", "worst": "bad",
+	"further": "far", "furthest": "far", "farther": "far", "farthest": "far",
+	"less": "little", "least": "little", "more": "many", "most": "many",
+	"elder": "old", "eldest": "old",
+}
+
+// irregularAdverbs maps irregular comparative and superlative adverbs to
+// their base forms.
+var irregularAdverbs = map[string]string{
+	"better": "well", "best": "well", "worse": "badly", "worst": "badly",
+	"further": "far", "furthest": "far", "farther": "far", "farthest": "far",
+	"less": "little", "least": "little", "more": "much", "most": "much",
+}
+
+// lemmaExceptions maps a POS tag to the irregular forms it applies to.
+var lemmaExceptions = map[string]map[string]string{
+	"VB":  irregularVerbs,
+	"VBD": irregularVerbs,
+	"VBG": irregularVerbs,
+	"VBN": irregularVerbs,
+	"VBP": irregularVerbs,
+	"VBZ": irregularVerbs,
+	"NNS": irregularNouns,
+	"JJR": irregularAdjectives,
+	"JJS": irregularAdjectives,
+	"RBR": irregularAdverbs,
+	"RBS": irregularAdverbs,
+	"MD": {
+		"'ll": "will", "wo": "will", "'d": "would", "ca": "can",
+		"sha": "shall"},
+	"RB": {"n't": "not"},
+}
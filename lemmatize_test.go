@@ -0,0 +1,142 @@
+package prose
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLemmatize(t *testing.T) {
+	tests := []struct {
+		word, tag, lemma string
+	}{
+		// Nouns
+		{"cities", "NNS", "city"},
+		{"boxes", "NNS", "box"},
+		{"classes", "NNS", "class"},
+		{"cases", "NNS", "case"},
+		{"heroes", "NNS", "hero"},
+		{"firemen", "NNS", "fireman"},
+		{"mice", "NNS", "mouse"},
+		{"children", "NNS", "child"},
+		{"wolves", "NNS", "wolf"},
+		{"Dogs", "NNS", "dog"},
+		{"status", "NN", "status"},
+		{"buses", "NNS", "bus"},
+		{"viruses", "NNS", "virus"},
+		{"causes", "NNS", "cause"},
+		{"houses", "NNS", "house"},
+		{"lenses", "NNS", "lens"},
+		{"Americans", "NNPS", "American"},
+		{"Paris", "NNP", "Paris"},
+
+		// Verbs
+		{"was", "VBD", "be"},
+		{"'s", "VBZ", "be"},
+		{"runs", "VBZ", "run"},
+		{"watches", "VBZ", "watch"},
+		{"carries", "VBZ", "carry"},
+		{"focuses", "VBZ", "focus"},
+		{"buses", "VBZ", "bus"},
+		{"causes", "VBZ", "cause"},
+		{"uses", "VBZ", "use"},
+		{"excuses", "VBZ", "excuse"},
+		{"running", "VBG", "run"},
+		{"making", "VBG", "make"},
+		{"using", "VBG", "use"},
+		{"seeing", "VBG", "see"},
+		{"falling", "VBG", "fall"},
+		{"playing", "VBG", "play"},
+		{"loving", "VBG", "love"},
+		{"raining", "VBG", "rain"},
+		{"realizing", "VBG", "realize"},
+		{"walked", "VBD", "walk"},
+		{"stopped", "VBD", "stop"},
+		{"hoped", "VBD", "hope"},
+		{"tried", "VBD", "try"},
+		{"agreed", "VBD", "agree"},
+		{"visited", "VBD", "visit"},
+		{"opened", "VBN", "open"},
+		{"controlled", "VBN", "control"},
+		{"missed", "VBD", "miss"},
+		{"judged", "VBN", "judge"},
+		{"handled", "VBN", "handle"},
+		{"related", "VBN", "relate"},
+		{"required", "VBN", "require"},
+		{"wrote", "VBD", "write"},
+		{"written", "VBN", "write"},
+		{"lying", "VBG", "lie"},
+		{"dying", "VBG", "die"},
+		{"died", "VBD", "die"},
+		{"lied", "VBD", "lie"},
+		{"crying", "VBG", "cry"},
+		{"created", "VBD", "create"},
+		{"creating", "VBG", "create"},
+		{"negotiated", "VBN", "negotiate"},
+		{"heated", "VBN", "heat"},
+		{"Went", "VBD", "go"},
+
+		// Adjectives and adverbs
+		{"bigger", "JJR", "big"},
+		{"happiest", "JJS", "happy"},
+		{"larger", "JJR", "large"},
+		{"nicest", "JJS", "nice"},
+		{"longer", "JJR", "long"},
+		{"cheaper", "JJR", "cheap"},
+		{"simpler", "JJR", "simple"},
+		{"better", "JJR", "good"},
+		{"better", "RBR", "well"},
+		{"worst", "JJS", "bad"},
+
+		// Everything else
+		{"n't", "RB", "not"},
+		{"'ll", "MD", "will"},
+		{"ca", "MD", "can"},
+		{"I", "PRP", "I"},
+		{"The", "DT", "the"},
+		{"quickly", "RB", "quickly"},
+	}
+
+	for _, test := range tests {
+		if lemma := Lemmatize(test.word, test.tag); lemma != test.lemma {
+			t.Errorf("Lemmatize(%q, %q) expected = %q, got = %q",
+				test.word, test.tag, test.lemma, lemma)
+		}
+	}
+}
+
+func TestLemmatizerComponent(t *testing.T) {
+	doc := makeTagged(
+		"The|DT children|NNS were|VBD playing|VBG with|IN bigger|JJR " +
+			"boxes|NNS .|.")
+	checkError(LemmatizerComponent().Process(doc))
+
+	expected := []string{"the", "child", "be", "play", "with", "big", "box", "."}
+	observed := []string{}
+	for _, tok := range doc.Tokens() {
+		observed = append(observed, tok.Lemma)
+	}
+
+	if !reflect.DeepEqual(observed, expected) {
+		t.Errorf("LemmatizerComponent() expected = %v, got = %v", expected, observed)
+	}
+}
+
+func TestWithLemmatization(t *testing.T) {
+	opts := defaultOpts
+	WithLemmatization(true)(&Document{}, &opts)
+	WithTagging(false)(&Document{}, &opts)
+	WithExtraction(false)(&Document{}, &opts)
+
+	pipeline, err := opts.pipeline()
+	checkError(err)
+
+	names := []string{}
+	for _, component := range pipeline {
+		names = append(names, component.Name())
+	}
+
+	expected := []string{SegmenterName, TokenizerName, TaggerName, LemmatizerName}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("WithLemmatization() expected = %v, got = %v", expected, names)
+	}
+}
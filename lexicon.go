@@ -0,0 +1,235 @@
+package prose
+
+// sentimentLexicon maps words to their valence, on a scale from -4 (most
+// negative) to +4 (most positive).
+//
+// It follows the format and scale of the VADER lexicon, covering common
+// English sentiment-bearing words (including inflected forms, which we
+// don't lemmatize away) and some informal spellings.
+var sentimentLexicon = map[string]float64{
+	// Positive
+	"able": 0.8, "absolutely": 0.6, "accept": 1.6, "accepted": 1.1,
+	"accomplish": 1.8, "accomplished": 1.9, "achieve": 1.8, "achievement": 2.1,
+	"admire": 2.1, "admired": 2.3, "adorable": 2.2, "adore": 2.6, "adored": 2.6,
+	"advantage": 1.0, "affection": 2.4, "agree": 1.5, "agreed": 1.1,
+	"alive": 1.6, "amaze": 2.5, "amazed": 2.2, "amazing": 2.8, "amazingly": 2.8,
+	"amuse": 1.7, "amused": 1.8, "amusing": 1.6, "appreciate": 1.7,
+	"appreciated": 2.3, "approval": 2.1, "approve": 1.9, "attractive": 1.9,
+	"awesome": 3.1, "beautiful": 2.9, "beautifully": 2.7, "beauty": 2.8,
+	"beloved": 2.3, "benefit": 2.0, "benefits": 1.6, "best": 3.2,
+	"better": 1.9, "bless": 1.8, "blessed": 2.9, "bliss": 2.7, "bold": 1.6,
+	"bonus": 2.5, "brave": 2.4, "bravo": 2.6, "breathtaking": 2.6,
+	"brilliant": 2.8, "bright": 1.9, "calm": 1.3, "care": 2.2, "cared": 1.8,
+	"careful": 0.6, "cares": 2.0, "caring": 2.2, "celebrate": 2.7,
+	"celebrated": 2.7, "celebration": 2.6, "charm": 1.7, "charming": 2.8,
+	"cheer": 2.3, "cheerful": 2.5, "cheers": 2.1, "cherish": 1.6,
+	"clean": 1.7, "clever": 2.0, "comfort": 1.5, "comfortable": 2.3,
+	"commend": 1.9, "compassion": 2.0, "confidence": 2.3, "confident": 2.2,
+	"congrats": 2.4, "congratulations": 2.9, "cool": 1.3, "courage": 2.2,
+	"courteous": 2.3, "creative": 1.9, "cute": 2.0, "delight": 2.9,
+	"delighted": 2.3, "delightful": 2.9, "dependable": 1.9, "desire": 1.7,
+	"easy": 1.9, "ecstatic": 2.3, "effective": 2.1, "efficient": 1.8,
+	"elegant": 2.1, "enjoy": 2.2, "enjoyed": 2.3, "enjoying": 2.4,
+	"enjoys": 2.3, "enthusiasm": 1.9, "enthusiastic": 2.2, "excellence": 3.1,
+	"excellent": 2.7, "excite": 2.1, "excited": 1.4, "excitement": 2.2,
+	"exciting": 2.2, "fabulous": 2.4, "fair": 1.3, "faith": 1.8,
+	"faithful": 1.9, "fame": 1.9, "fan": 1.3, "fantastic": 2.6,
+	"fascinating": 2.5, "favor": 1.7, "favorite": 2.0, "favourite": 2.0,
+	"fine": 0.8, "fit": 1.5, "flawless": 2.3, "fond": 1.9, "forgive": 1.1,
+	"fortunate": 1.9, "free": 2.3, "freedom": 3.2, "fresh": 1.3,
+	"friend": 2.2, "friendly": 2.2, "friends": 2.1, "fun": 2.3, "funny": 1.9,
+	"generous": 2.3, "genius": 1.9, "gentle": 1.9, "gift": 1.9, "glad": 2.0,
+	"glamorous": 1.9, "glory": 2.3, "good": 1.9, "goodness": 2.0,
+	"gorgeous": 3.0, "grace": 1.8, "graceful": 2.0, "grand": 2.0,
+	"grateful": 2.0, "gratitude": 2.3, "great": 3.1, "greatest": 3.2,
+	"happier": 2.4, "happiest": 3.2, "happily": 2.6, "happiness": 2.6,
+	"happy": 2.7, "harmony": 1.7, "heal": 1.4, "healthy": 1.7, "heart": 1.6,
+	"heaven": 2.3, "help": 1.7, "helped": 1.8, "helpful": 1.8, "helping": 1.2,
+	"hero": 2.6, "honest": 2.3, "honor": 2.2, "hope": 1.9, "hopeful": 2.3,
+	"hug": 2.1, "hugs": 2.2, "humor": 1.1, "ideal": 2.4, "importance": 1.5,
+	"important": 0.8, "impress": 1.9, "impressed": 2.1, "impressive": 2.3,
+	"improve": 1.9, "improved": 2.1, "improvement": 2.0, "incredible": 2.0,
+	"innovative": 1.9, "inspiration": 2.4, "inspire": 2.7, "inspired": 2.2,
+	"inspiring": 1.9, "intelligent": 2.0, "interest": 2.0, "interested": 1.7,
+	"interesting": 1.7, "joke": 1.2, "joy": 2.8, "joyful": 2.9, "keen": 1.5,
+	"kind": 2.4, "kindly": 2.2, "kindness": 2.0, "kiss": 1.8, "laugh": 2.6,
+	"laughed": 2.0, "laughing": 2.2, "like": 1.5, "liked": 1.8, "likes": 1.8,
+	"lmao": 2.9, "lol": 1.8, "love": 3.2, "loved": 2.9, "lovely": 2.8,
+	"lover": 2.8, "loves": 2.7, "loving": 2.9, "loyal": 2.1, "lucky": 1.8,
+	"magnificent": 2.9, "marvelous": 2.9, "masterpiece": 3.1, "merry": 2.5,
+	"miracle": 2.8, "motivated": 1.9, "neat": 2.0, "nice": 1.8,
+	"nicely": 1.9, "ok": 1.2, "okay": 0.9, "optimism": 2.5,
+	"optimistic": 1.3, "outstanding": 3.0, "paradise": 3.2, "passion": 2.0,
+	"passionate": 2.4, "patient": 1.6, "peace": 2.5, "peaceful": 2.2,
+	"perfect": 2.7, "perfectly": 3.2, "pleasant": 2.3, "please": 1.3,
+	"pleased": 1.9, "pleasure": 2.7, "polite": 2.0, "popular": 1.8,
+	"positive": 2.6, "powerful": 1.8, "praise": 2.6, "precious": 2.7,
+	"pretty": 2.2, "pride": 1.4, "prize": 2.3, "progress": 1.8,
+	"promise": 1.3, "protect": 1.3, "proud": 2.1, "quality": 1.5,
+	"ready": 1.5, "recommend": 1.5, "recommended": 0.8, "relax": 1.9,
+	"relaxed": 2.2, "relief": 2.1, "reliable": 2.1, "relieved": 1.6,
+	"remarkable": 2.6, "resolve": 1.6, "resolved": 0.7, "respect": 2.1,
+	"reward": 2.7, "rich": 2.6, "right": 1.0, "romantic": 2.3, "safe": 1.9,
+	"satisfied": 1.8, "satisfy": 2.0, "satisfying": 2.0, "save": 2.2,
+	"secure": 1.4, "sexy": 2.4, "share": 1.2, "shine": 1.3, "smart": 1.7,
+	"smile": 1.5, "smiled": 2.5, "smiles": 2.1, "smiling": 1.5,
+	"smooth": 1.1, "solid": 1.7, "solution": 1.5, "solved": 1.1,
+	"special": 1.7, "spectacular": 2.8, "splendid": 2.8, "stable": 1.2,
+	"strength": 2.2, "strong": 2.3, "stunning": 1.6, "success": 2.7,
+	"successful": 2.8, "sunshine": 2.2, "super": 2.9, "superb": 3.1,
+	"support": 1.7, "supported": 1.3, "supportive": 1.9, "sure": 1.3,
+	"surprise": 1.1, "sweet": 2.0, "sweetheart": 3.3, "talent": 1.8,
+	"talented": 2.3, "terrific": 2.5, "thank": 1.5, "thankful": 2.7,
+	"thanks": 1.9, "thrilled": 1.9, "top": 0.8, "treasure": 1.2,
+	"trust": 2.3, "trusted": 2.1, "truth": 1.3, "useful": 1.9,
+	"valuable": 2.1, "value": 1.4, "victory": 2.8, "warm": 0.9,
+	"warmth": 2.0, "wealth": 2.2, "welcome": 2.0, "well": 1.1, "win": 2.8,
+	"winner": 2.8, "winning": 2.4, "wins": 2.7, "wisdom": 2.4, "wise": 2.1,
+	"wish": 1.7, "won": 2.7, "wonderful": 2.7, "worth": 0.9, "wow": 2.8,
+	"yay": 2.4, "yes": 1.7, "yummy": 2.4,
+
+	// Negative
+	"abandon": -1.9, "abandoned": -2.0, "abuse": -3.2, "abused": -2.3,
+	"abusive": -3.2, "accident": -2.1, "ache": -1.6, "aches": -1.0,
+	"afraid": -2.2, "aggressive": -0.6, "agony": -1.8, "alarm": -1.4,
+	"alarmed": -1.4, "alone": -1.0, "anger": -2.7, "angry": -2.3,
+	"anguish": -2.9, "annoy": -1.9, "annoyed": -1.6, "annoying": -1.7,
+	"anxiety": -0.7, "anxious": -1.0, "apathy": -1.2, "argue": -1.4,
+	"argument": -1.5, "arrogant": -2.2, "ashamed": -2.1, "attack": -2.1,
+	"awful": -2.0, "awkward": -0.6, "bad": -2.5, "badly": -2.1,
+	"ban": -2.6, "bankrupt": -2.6, "bitter": -1.8, "blame": -1.4,
+	"blamed": -2.1, "bored": -1.1, "boring": -1.3, "broke": -1.8,
+	"broken": -2.1, "brutal": -3.1, "bug": -0.8, "bugs": -0.8,
+	"bullshit": -2.8, "burden": -1.9, "careless": -1.5, "catastrophe": -3.4,
+	"cheat": -2.0, "cheated": -2.5, "complain": -1.5, "complained": -1.7,
+	"complaint": -1.2, "confused": -1.3, "confusing": -0.9, "corrupt": -3.0,
+	"crap": -1.6, "crash": -1.7, "crashed": -1.7, "crazy": -1.4,
+	"crime": -2.5, "crisis": -3.1, "critical": -1.3, "cruel": -2.8,
+	"crush": -0.6, "cry": -2.1, "crying": -2.1, "damage": -2.2,
+	"damaged": -1.9, "damn": -1.7, "danger": -2.4, "dangerous": -2.1,
+	"dead": -3.3, "death": -2.9, "defeat": -2.0, "defeated": -2.1,
+	"defect": -1.4, "defective": -1.9, "delay": -1.3, "delayed": -0.9,
+	"depressed": -2.3, "depressing": -1.6, "depression": -2.7,
+	"desperate": -1.3, "despise": -1.4, "destroy": -2.5, "destroyed": -3.4,
+	"destruction": -2.7, "difficult": -1.5, "dirty": -1.9, "disappoint": -2.3,
+	"disappointed": -1.9, "disappointing": -2.2, "disappointment": -2.3,
+	"disaster": -3.1, "disgust": -2.9, "disgusting": -2.4, "dishonest": -2.7,
+	"dislike": -1.6, "dismal": -3.0, "distress": -2.4, "doubt": -1.5,
+	"dread": -2.4, "dreadful": -2.7, "dull": -1.7, "dumb": -2.3,
+	"embarrassed": -1.5, "embarrassing": -1.6, "enemy": -2.5, "error": -1.7,
+	"errors": -1.4, "evil": -3.4, "fail": -2.5, "failed": -2.3,
+	"failing": -2.3, "fails": -1.8, "failure": -2.3, "fake": -2.1,
+	"false": -1.2, "fault": -1.7, "faulty": -1.8, "fear": -2.2,
+	"fearful": -2.2, "fight": -1.6, "filthy": -2.8, "foolish": -1.1,
+	"fraud": -2.8, "frightened": -1.9, "frustrated": -2.4,
+	"frustrating": -1.9, "frustration": -2.1, "furious": -2.7, "garbage": -1.5,
+	"gloomy": -0.6, "greed": -1.7, "greedy": -1.3, "grief": -2.2,
+	"gross": -2.1, "guilt": -1.1, "guilty": -1.8, "harm": -2.5,
+	"harmful": -2.3, "harsh": -1.9, "hate": -2.7, "hated": -3.2,
+	"hates": -1.9, "hating": -2.3, "hatred": -3.2, "hell": -3.6,
+	"helpless": -2.0, "horrible": -2.5, "horrific": -3.4, "horror": -2.7,
+	"hostile": -2.2, "hurt": -2.4, "hurting": -1.7, "hurts": -2.1,
+	"idiot": -2.3, "ignorant": -1.1, "ignore": -1.5, "ignored": -1.3,
+	"ill": -1.8, "inadequate": -1.7, "incompetent": -2.5, "inferior": -1.7,
+	"insane": -1.7, "insult": -2.3, "insulted": -2.3, "irritated": -1.8,
+	"irritating": -2.0, "jealous": -2.0, "junk": -1.2, "kill": -3.7,
+	"killed": -3.5, "lame": -1.8, "late": -0.5, "lazy": -1.5, "liar": -3.1,
+	"lie": -1.6, "lied": -1.6, "lies": -1.8, "lonely": -1.5, "lose": -1.7,
+	"loser": -2.4, "loss": -1.3, "lost": -1.3, "lousy": -2.5, "mad": -2.2,
+	"mess": -1.5, "messed": -1.4, "messy": -1.5, "miserable": -2.2,
+	"misery": -2.7, "miss": -0.6, "missed": -1.2, "missing": -1.2,
+	"mistake": -1.4, "mistakes": -1.5, "mourn": -1.9, "nasty": -2.6,
+	"negative": -2.7, "neglect": -2.0, "nervous": -1.1, "nightmare": -3.0,
+	"noisy": -0.7, "nonsense": -1.7, "nuisance": -1.8, "offended": -1.0,
+	"offensive": -2.8, "outrage": -2.3, "pain": -2.3, "painful": -1.9,
+	"panic": -2.3, "pathetic": -2.7, "pessimistic": -1.5, "poor": -2.1,
+	"poorly": -1.7, "problem": -1.7, "problems": -1.7, "punish": -2.4,
+	"rage": -2.6, "regret": -1.8, "regrets": -1.5, "reject": -1.7,
+	"rejected": -2.3, "ridiculous": -1.5, "rotten": -2.3, "rubbish": -1.9,
+	"rude": -2.0, "ruin": -2.8, "ruined": -2.4, "sad": -2.1, "sadly": -1.8,
+	"sadness": -1.9, "scam": -2.7, "scandal": -1.9, "scared": -1.9,
+	"scary": -2.2, "selfish": -2.1, "severe": -1.6, "shame": -2.1,
+	"shameful": -2.2, "shit": -2.6, "shitty": -2.6, "shock": -1.6,
+	"shocked": -1.3, "sick": -2.3, "slow": -0.9, "sorrow": -2.4,
+	"sorry": -0.3, "stinks": -1.7, "stolen": -2.2, "stress": -1.8,
+	"stressed": -1.4, "stressful": -2.3, "stuck": -1.0, "stupid": -2.4,
+	"suck": -1.9, "sucks": -1.5, "suffer": -2.5, "suffering": -2.1,
+	"suicide": -3.5, "terrible": -2.1, "terribly": -2.6, "terrified": -3.0,
+	"terror": -3.4, "threat": -2.4, "tired": -1.9, "tragedy": -3.4,
+	"tragic": -3.4, "trash": -1.8, "trouble": -1.7, "ugly": -2.3,
+	"unacceptable": -2.0, "unfair": -2.1, "unfortunate": -2.0,
+	"unfortunately": -1.4, "unhappy": -1.8, "unhelpful": -1.8,
+	"unpleasant": -2.1, "unreliable": -1.9, "unstable": -1.5, "upset": -1.6,
+	"useless": -1.8, "victim": -2.2, "violence": -3.1, "violent": -2.9,
+	"vulnerable": -0.9, "war": -2.9, "waste": -1.8, "wasted": -2.2,
+	"weak": -1.9, "weakness": -1.8, "weird": -0.7, "worried": -1.2,
+	"worry": -1.9, "worse": -2.1, "worst": -3.1, "worthless": -1.9,
+	"wrong": -2.1, "wtf": -2.8,
+}
+
+// sentimentBoosters maps intensifiers (e.g., "very") and dampeners (e.g.,
+// "slightly") to the change in valence that they cause.
+var sentimentBoosters = map[string]float64{
+	"absolutely": sentimentBoost, "amazingly": sentimentBoost,
+	"awfully": sentimentBoost, "completely": sentimentBoost,
+	"considerably": sentimentBoost, "decidedly": sentimentBoost,
+	"deeply": sentimentBoost, "effing": sentimentBoost,
+	"enormously": sentimentBoost, "entirely": sentimentBoost,
+	"especially": sentimentBoost, "exceptionally": sentimentBoost,
+	"extremely": sentimentBoost, "fabulously": sentimentBoost,
+	"flipping": sentimentBoost, "freaking": sentimentBoost,
+	"fucking": sentimentBoost, "fully": sentimentBoost,
+	"greatly": sentimentBoost, "hella": sentimentBoost,
+	"highly": sentimentBoost, "hugely": sentimentBoost,
+	"incredibly": sentimentBoost, "intensely": sentimentBoost,
+	"majorly": sentimentBoost, "more": sentimentBoost, "most": sentimentBoost,
+	"particularly": sentimentBoost, "purely": sentimentBoost,
+	"quite": sentimentBoost, "really": sentimentBoost,
+	"remarkably": sentimentBoost, "so": sentimentBoost,
+	"substantially": sentimentBoost, "thoroughly": sentimentBoost,
+	"totally": sentimentBoost, "tremendously": sentimentBoost,
+	"uber": sentimentBoost, "unbelievably": sentimentBoost,
+	"unusually": sentimentBoost, "utterly": sentimentBoost,
+	"very": sentimentBoost,
+
+	"almost": -sentimentBoost, "barely": -sentimentBoost,
+	"hardly": -sentimentBoost, "kinda": -sentimentBoost,
+	"kindof": -sentimentBoost, "less": -sentimentBoost,
+	"little": -sentimentBoost, "marginally": -sentimentBoost,
+	"occasionally": -sentimentBoost, "partly": -sentimentBoost,
+	"scarcely": -sentimentBoost, "slightly": -sentimentBoost,
+	"somewhat": -sentimentBoost, "sorta": -sentimentBoost,
+	"sortof": -sentimentBoost,
+}
+
+// sentimentNegations are the words that invert the valence of the words
+// that follow them (in addition to any word ending in "n't").
+var sentimentNegations = map[string]struct{}{
+	"aint": {}, "arent": {}, "cannot": {}, "cant": {}, "couldnt": {},
+	"darent": {}, "didnt": {}, "doesnt": {}, "dont": {}, "hadnt": {},
+	"hasnt": {}, "havent": {}, "isnt": {}, "mightnt": {}, "mustnt": {},
+	"neither": {}, "never": {}, "none": {}, "nope": {}, "nor": {}, "not": {},
+	"nothing": {}, "nowhere": {}, "oughtnt": {}, "shant": {}, "shouldnt": {},
+	"wasnt": {}, "werent": {}, "without": {}, "wont": {}, "wouldnt": {},
+	"rarely": {}, "seldom": {}, "despite": {},
+}
+
+// emoticonValence gives the valence of the emoticons recognized by the
+// tokenizer (see `emoticons`), along with a few common ones that it splits
+// apart (which mergeEmoticons re-joins).
+var emoticonValence = map[string]float64{
+	"(-8": 1.3, "(-;": 1.5, "(-_-)": -0.8, "(._.)": -1.2, "(:": 1.6,
+	"(=": 1.5, "(o:": 1.6, "(¬_¬)": -0.9, "(ಠ_ಠ)": -1.6,
+	"(╯°□°）╯︵┻━┻": -2.1, "-__-": -0.9, "8-)": 1.9, "8-D": 2.3, "8D": 2.3,
+	":(": -1.9, ":((": -2.2, ":(((": -2.5, ":()": -0.4, ":)))": 2.4,
+	":-)": 1.3, ":-))": 2.1, ":-)))": 2.4, ":-*": 1.7, ":-/": -1.2,
+	":-X": -1.0, ":-]": 1.4, ":-o": -0.4, ":-p": 1.4, ":-x": -1.0,
+	":-|": -0.7, ":-}": 1.4, ":0": -0.4, ":3": 2.3, ":P": 1.4, ":]": 2.0,
+	":`(": -2.4, ":`)": 1.5, ":`-(": -2.4, ":o": -0.4, ":o)": 1.5,
+	"=(": -1.8, "=)": 1.6, "=D": 2.3, "=|": -0.8, "@_@": -0.6, "O.o": -0.6,
+	"O_o": -0.6, "V_V": -1.2, "XDD": 2.8, "[-:": 1.4, "^___^": 2.5,
+	"o_0": -0.6, "o_O": -0.6, "o_o": -0.6, "v_v": -1.2, "xD": 2.5,
+	"xDD": 2.8, "¯\\(ツ)/¯": 0,
+	":)": 2.0, ":D": 2.3, ";)": 1.7, ";-)": 1.7, ":/": -1.4, "<3": 1.9,
+	"</3": -1.8, ":'(": -2.2,
+}
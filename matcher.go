@@ -0,0 +1,250 @@
+package prose
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// A TokenPattern describes a single token (or, with a quantifier, a run of
+// tokens) within a Matcher pattern.
+//
+// A token matches if it satisfies every predicate that's set; a TokenPattern
+// without any predicates matches any token.
+type TokenPattern struct {
+	Text  string `json:"text,omitempty"`  // The token's exact text.
+	Lower string `json:"lower,omitempty"` // The token's lowercased text.
+	Regex string `json:"regex,omitempty"` // A regular expression matching (part of) the token's text.
+	Tag   string `json:"tag,omitempty"`   // The token's Penn Treebank tag (e.g., "NNP").
+	Shape string `json:"shape,omitempty"` // The token's shape (e.g., "upcase"; see below).
+
+	// Label is the token's IOB label (e.g., "B-GPE"). A label without a
+	// prefix (e.g., "GPE") matches tokens at any position within an entity.
+	Label string `json:"label,omitempty"`
+
+	IsPunct *bool `json:"is_punct,omitempty"` // If set, whether the token is punctuation.
+	IsDigit *bool `json:"is_digit,omitempty"` // If set, whether the token consists of digits.
+
+	// Op is an optional quantifier: "?" (zero or one), "*" (zero or more) or
+	// "+" (one or more).
+	Op string `json:"op,omitempty"`
+}
+
+// A Match is a span of tokens that matched one of a Matcher's patterns.
+type Match struct {
+	Span
+
+	Name string // The name of the matched pattern.
+	Text string // The matched text.
+
+	tokens []*Token
+}
+
+// Tokens returns the tokens that make up `m`.
+func (m Match) Tokens() []Token {
+	tokens := make([]Token, 0, len(m.tokens))
+	for _, tok := range m.tokens {
+		tokens = append(tokens, *tok)
+	}
+	return tokens
+}
+
+// A Matcher finds sequences of tokens described by patterns, similar to
+// spaCy's rule-based Matcher.
+//
+// Patterns are sequences of TokenPatterns, whose quantifiers are matched
+// greedily. Token shapes are the same as those used by the NER model:
+// "number", "punct", "downcase", "upcase", "mixedcase" and "other".
+type Matcher struct {
+	patterns []matcherPattern
+}
+
+type matcherPattern struct {
+	name   string
+	tokens []tokenMatcher
+}
+
+type tokenMatcher struct {
+	TokenPattern
+	regex *regexp.Regexp
+}
+
+// NewMatcher creates a Matcher without any patterns.
+func NewMatcher() *Matcher {
+	return &Matcher{}
+}
+
+// Add registers `patterns` under the given name, which is reported for each
+// of their matches.
+func (m *Matcher) Add(name string, patterns ...[]TokenPattern) error {
+	compiled := make([]matcherPattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		if len(pattern) == 0 {
+			return errors.New("prose: pattern '" + name + "' is empty")
+		}
+
+		tokens := make([]tokenMatcher, len(pattern))
+		for i, tp := range pattern {
+			switch tp.Op {
+			case "", "?", "*", "+":
+			default:
+				return fmt.Errorf("prose: pattern '%s' has an invalid quantifier %q", name, tp.Op)
+			}
+
+			tokens[i] = tokenMatcher{TokenPattern: tp}
+			if tp.Regex != "" {
+				re, err := regexp.Compile(tp.Regex)
+				if err != nil {
+					return fmt.Errorf("prose: pattern '%s': %v", name, err)
+				}
+				tokens[i].regex = re
+			}
+		}
+
+		compiled = append(compiled, matcherPattern{name: name, tokens: tokens})
+	}
+
+	m.patterns = append(m.patterns, compiled...)
+	return nil
+}
+
+// Load registers the patterns read from `reader`, which must contain a JSON
+// array of named patterns:
+//
+//	[
+//	  {"name": "HELLO", "pattern": [{"lower": "hello"}, {"is_punct": true, "op": "?"}]}
+//	]
+func (m *Matcher) Load(reader io.Reader) error {
+	var entries []struct {
+		Name    string         `json:"name"`
+		Pattern []TokenPattern `json:"pattern"`
+	}
+	if err := json.NewDecoder(reader).Decode(&entries); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := m.Add(entry.Name, entry.Pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match finds the Matcher's patterns in `doc`.
+//
+// Each pattern is matched greedily from left to right: at every position the
+// longest match is taken, and the search continues after it. Matches of
+// different patterns may overlap, but no match crosses a sentence boundary.
+// The matches are sorted by position.
+func (m *Matcher) Match(doc *Document) []Match {
+	var matches []Match
+	for _, group := range doc.tokenGroups() {
+		for _, pattern := range m.patterns {
+			for i := 0; i < len(group); {
+				end := pattern.match(group, i)
+				if end <= i {
+					i++
+					continue
+				}
+				chunk := makeChunk(doc.Text, group[i:end], pattern.name, 0)
+				matches = append(matches, Match{
+					Span:   chunk.Span,
+					Name:   pattern.name,
+					Text:   chunk.Text,
+					tokens: group[i:end],
+				})
+				i = end
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Start < matches[j].Start
+	})
+	return matches
+}
+
+// match returns the end of the longest match of `p` starting at `tokens[start]`,
+// or -1 if there's none.
+//
+// It tracks the set of positions reachable after each of the pattern's
+// tokens, so quantifiers never require backtracking.
+func (p matcherPattern) match(tokens []*Token, start int) int {
+	states := map[int]bool{start: true}
+	for _, tm := range p.tokens {
+		next := make(map[int]bool)
+		for pos := range states {
+			if tm.Op == "?" || tm.Op == "*" {
+				next[pos] = true
+			}
+			for k := pos; k < len(tokens) && tm.matches(tokens[k]); k++ {
+				next[k+1] = true
+				if tm.Op == "" || tm.Op == "?" {
+					break
+				}
+			}
+		}
+		if len(next) == 0 {
+			return -1
+		}
+		states = next
+	}
+
+	end := -1
+	for pos := range states {
+		if pos > end {
+			end = pos
+		}
+	}
+	return end
+}
+
+// matches determines if `tok` satisfies all of `tm`'s predicates.
+func (tm tokenMatcher) matches(tok *Token) bool {
+	switch {
+	case tm.Text != "" && tok.Text != tm.Text:
+		return false
+	case tm.Lower != "" && strings.ToLower(tok.Text) != tm.Lower:
+		return false
+	case tm.regex != nil && !tm.regex.MatchString(tok.Text):
+		return false
+	case tm.Tag != "" && tok.Tag != tm.Tag:
+		return false
+	case tm.Label != "" && !labelMatches(tok.Label, tm.Label):
+		return false
+	case tm.Shape != "" && shape(tok.Text) != tm.Shape:
+		return false
+	case tm.IsPunct != nil && (tok.Text != "" && isPunct(tok.Text)) != *tm.IsPunct:
+		return false
+	case tm.IsDigit != nil && isDigits(tok.Text) != *tm.IsDigit:
+		return false
+	}
+	return true
+}
+
+// labelMatches determines if the IOB label `label` matches `want`, which may
+// omit the "B-" or "I-" prefix.
+func labelMatches(label, want string) bool {
+	if label == want {
+		return true
+	} else if strings.Contains(want, "-") || len(label) < 2 {
+		return false
+	}
+	return (label[:2] == "B-" || label[:2] == "I-") && label[2:] == want
+}
+
+// isDigits determines if `word` consists only of digits.
+func isDigits(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return word != ""
+}
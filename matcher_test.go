@@ -0,0 +1,88 @@
+package prose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func matchStrings(matches []Match) []string {
+	observed := []string{}
+	for _, m := range matches {
+		observed = append(observed, m.Text+"/"+m.Name)
+	}
+	return observed
+}
+
+func TestMatcherQuantifiers(t *testing.T) {
+	doc := makeTagged(
+		"The|DT very|RB very|RB big|JJ dog|NN barked|VBD at|IN a|DT " +
+			"cat|NN .|. Dogs|NNS bark|VBP .|.")
+
+	yes := true
+	m := NewMatcher()
+	checkError(m.Add("ADV_ADJ",
+		[]TokenPattern{{Tag: "RB", Op: "+"}, {Tag: "JJ"}}))
+	checkError(m.Add("NP",
+		[]TokenPattern{{Tag: "DT", Op: "?"}, {Regex: "^(very|big)$", Op: "*"}, {Lower: "dog"}},
+		[]TokenPattern{{Tag: "DT"}, {Text: "cat"}, {IsPunct: &yes}}))
+
+	expected := []string{
+		"The very very big dog/NP",
+		"very very big/ADV_ADJ",
+		"a cat ./NP",
+	}
+	if observed := matchStrings(m.Match(doc)); !reflect.DeepEqual(observed, expected) {
+		t.Errorf("Match() = %v, expected %v", observed, expected)
+	}
+}
+
+func TestMatcherPredicates(t *testing.T) {
+	doc := makeLabeled("Call Jane Smith at 555 1234 in New York !",
+		"O", "B-PERSON", "I-PERSON", "O", "O", "O", "O", "B-GPE", "I-GPE", "O")
+
+	yes := true
+	m := NewMatcher()
+	checkError(m.Add("PERSON", []TokenPattern{{Label: "PERSON", Op: "+"}}))
+	checkError(m.Add("PHONE", []TokenPattern{{IsDigit: &yes}, {Shape: "number"}}))
+	checkError(m.Add("PLACE", []TokenPattern{{Label: "B-GPE"}, {Label: "I-GPE", Op: "*"}}))
+
+	matches := m.Match(doc)
+	expected := []string{"Jane Smith/PERSON", "555 1234/PHONE", "New York/PLACE"}
+	if observed := matchStrings(matches); !reflect.DeepEqual(observed, expected) {
+		t.Fatalf("Match() = %v, expected %v", observed, expected)
+	}
+
+	phone := matches[1]
+	if phone.Start != 19 || phone.End != 27 || len(phone.Tokens()) != 2 {
+		t.Errorf("Match() = %+v, expected offsets [19, 27) over 2 tokens", phone)
+	}
+}
+
+func TestMatcherLoad(t *testing.T) {
+	patterns := `[
+		{"name": "GREETING", "pattern": [{"lower": "hello"}, {"is_punct": true, "op": "?"}]},
+		{"name": "TITLED", "pattern": [{"regex": "^(Mr|Dr)\\.?$"}, {"is_punct": true, "op": "?"}, {"shape": "upcase"}]}
+	]`
+
+	m := NewMatcher()
+	checkError(m.Load(strings.NewReader(patterns)))
+
+	doc, err := NewDocument("Hello, Dr. Watson.", WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	expected := []string{"Hello,/GREETING", "Dr. Watson/TITLED"}
+	if observed := matchStrings(m.Match(doc)); !reflect.DeepEqual(observed, expected) {
+		t.Errorf("Match() = %v, expected %v", observed, expected)
+	}
+
+	for _, bad := range []string{
+		`[{"name": "BAD", "pattern": [{"text": "a", "op": "{2}"}]}]`,
+		`[{"name": "BAD", "pattern": [{"regex": "("}]}]`,
+		`[{"name": "BAD", "pattern": []}]`,
+	} {
+		if err := NewMatcher().Load(strings.NewReader(bad)); err == nil {
+			t.Errorf("Load(%s) succeeded, expected an error", bad)
+		}
+	}
+}
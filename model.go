@@ -1,18 +1,28 @@
 package prose
 
 import (
+	"errors"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
 )
 
 // A Model holds the structures and data used internally by prose.
+//
+// Once created, a Model is read-only: it's safe to share a single Model
+// between any number of goroutines, each creating its own Documents.
 type Model struct {
 	Name string
 
-	tagger    *perceptronTagger
-	extracter *entityExtracter
+	tagger     *perceptronTagger
+	extracter  *entityExtracter
+	parser     *dependencyParser
+	classifier *textClassifier
+
+	// err records the first failure encountered by a DataSource.
+	err error
 }
 
 // DataSource provides training data to a Model.
@@ -26,7 +36,15 @@ func UsingEntities(data []EntityContext) DataSource {
 // UsingEntities creates a NER from labeled data and custom tokenizer.
 func UsingEntitiesAndTokenizer(data []EntityContext, tokenizer Tokenizer) DataSource {
 	return func(model *Model) {
+		if model.tagger == nil {
+			model.err = errors.New("prose: training entities requires a tagger")
+			return
+		}
 		corpus := makeCorpus(data, model.tagger, tokenizer)
+		if len(corpus) == 0 {
+			model.err = errors.New("prose: no tokens found in the training data")
+			return
+		}
 		model.extracter = extracterFromData(corpus)
 	}
 }
@@ -51,28 +69,60 @@ type EntityContext struct {
 }
 
 // ModelFromData creates a new Model from user-provided training data.
+//
+// It panics if the model can't be created; see TrainModelFromData for a
+// version that returns an error instead.
 func ModelFromData(name string, sources ...DataSource) *Model {
-	model := defaultModel(true, true)
+	model, err := TrainModelFromData(name, sources...)
+	checkError(err)
+	return model
+}
+
+// TrainModelFromData creates a new Model from user-provided training data.
+func TrainModelFromData(name string, sources ...DataSource) (*Model, error) {
+	model, err := defaultModel(true, true, false)
+	if err != nil {
+		return nil, err
+	}
 	model.Name = name
 	for _, source := range sources {
 		source(model)
+		if model.err != nil {
+			return nil, model.err
+		}
 	}
-	return model
+	return model, nil
 }
 
 // ModelFromDisk loads a Model from the user-provided location.
+//
+// It panics if the model can't be loaded; see LoadModelFromDisk for a version
+// that returns an error instead.
 func ModelFromDisk(path string) *Model {
-	filesys := os.DirFS(path)
-	return &Model{
-		Name: filepath.Base(path),
+	model, err := LoadModelFromDisk(path)
+	checkError(err)
+	return model
+}
 
-		extracter: loadClassifier(filesys),
-		tagger:    newPerceptronTagger(),
-	}
+// LoadModelFromDisk loads a Model from the user-provided location.
+func LoadModelFromDisk(path string) (*Model, error) {
+	return loadModel(filepath.Base(path), os.DirFS(path))
 }
 
-// ModelFromFS loads a model from the
+// ModelFromFS loads a model from the first folder named `name` within
+// `filesys`.
+//
+// It panics if the model can't be loaded; see LoadModelFromFS for a version
+// that returns an error instead.
 func ModelFromFS(name string, filesys fs.FS) *Model {
+	model, err := LoadModelFromFS(name, filesys)
+	checkError(err)
+	return model
+}
+
+// LoadModelFromFS loads a model from the first folder named `name` within
+// `filesys`.
+func LoadModelFromFS(name string, filesys fs.FS) (*Model, error) {
 	// Locate a folder matching name within filesys
 	var modelFS fs.FS
 	err := fs.WalkDir(filesys, ".", func(path string, d fs.DirEntry, err error) error {
@@ -92,23 +142,88 @@ func ModelFromFS(name string, filesys fs.FS) *Model {
 		return nil
 	})
 	if err != io.EOF {
-		checkError(err)
+		if err == nil {
+			err = fs.ErrNotExist
+		}
+		return nil, assetError("load", name, err)
 	}
 
-	return &Model{
-		Name: name,
-
-		extracter: loadClassifier(modelFS),
-		tagger:    newPerceptronTagger(),
-	}
+	return loadModel(name, modelFS)
 }
 
 // Write saves a Model to the user-provided location.
 func (m *Model) Write(path string) error {
-	err := os.MkdirAll(path, os.ModePerm)
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return err
+	}
 	// m.Tagger.model.Marshal(path)
-	checkError(m.extracter.model.marshal(path))
-	return err
+	written := false
+	if m.parser != nil {
+		if err := m.parser.marshal(path); err != nil {
+			return err
+		}
+		written = true
+	}
+	if m.classifier != nil && m.classifier.model != nil {
+		if err := m.classifier.model.marshal(path, "TextClassifier"); err != nil {
+			return err
+		}
+		written = true
+	}
+	if m.extracter != nil && m.extracter.model != nil {
+		if err := m.extracter.model.marshal(path, "Maxent"); err != nil {
+			return err
+		}
+		written = true
+	}
+
+	if !written {
+		return errors.New("prose: model has no trained components to write")
+	}
+	return nil
+}
+
+func loadModel(name string, filesys fs.FS) (*Model, error) {
+	// Each of the trained components is optional.
+	var extracter *entityExtracter
+	var err error
+	if _, err = fs.Stat(filesys, "Maxent"); err == nil {
+		if extracter, err = loadClassifier(filesys); err != nil {
+			return nil, err
+		}
+	}
+
+	tagger, err := builtinTagger()
+	if err != nil {
+		return nil, err
+	}
+
+	var parser *dependencyParser
+	if _, err = fs.Stat(filesys, "DependencyParser"); err == nil {
+		parser, err = loadParser(func(folder, name string, v interface{}) error {
+			return loadDiskAsset(filesys, folder, name, v)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	classifier, err := loadTextClassifier(filesys)
+	if err != nil {
+		return nil, err
+	}
+
+	if extracter == nil && parser == nil && classifier == nil {
+		return nil, assetError("load", name, fs.ErrNotExist)
+	}
+
+	return &Model{
+		Name:       name,
+		extracter:  extracter,
+		tagger:     tagger,
+		parser:     parser,
+		classifier: classifier,
+	}, nil
 }
 
 /* TODO: External taggers
@@ -131,39 +246,56 @@ func loadTagger(path string) *perceptronTagger {
 	return newTrainedPerceptronTagger(model)
 }*/
 
-func loadClassifier(filesys fs.FS) *entityExtracter {
+func loadClassifier(filesys fs.FS) (*entityExtracter, error) {
+	model, err := loadMaxent(filesys, "Maxent")
+	if err != nil {
+		return nil, err
+	}
+	return newTrainedEntityExtracter(model), nil
+}
+
+// loadMaxent reads a binaryMaxentClassifier from the folder `folder` of
+// `filesys`.
+func loadMaxent(filesys fs.FS, folder string) (*binaryMaxentClassifier, error) {
 	var mapping map[string]int
 	var weights []float64
 	var labels []string
 
-	maxent, err := fs.Sub(filesys, "Maxent")
-	checkError(err)
-
-	file, err := maxent.Open("mapping.gob")
-	checkError(err)
-	checkError(getDiskAsset(file).Decode(&mapping))
-
-	file, err = maxent.Open("weights.gob")
-	checkError(err)
-	checkError(getDiskAsset(file).Decode(&weights))
-
-	file, err = maxent.Open("labels.gob")
-	checkError(err)
-	checkError(getDiskAsset(file).Decode(&labels))
+	if err := loadDiskAsset(filesys, folder, "mapping.gob", &mapping); err != nil {
+		return nil, err
+	}
+	if err := loadDiskAsset(filesys, folder, "weights.gob", &weights); err != nil {
+		return nil, err
+	}
+	if err := loadDiskAsset(filesys, folder, "labels.gob", &labels); err != nil {
+		return nil, err
+	}
 
-	model := newMaxentClassifier(weights, mapping, labels)
-	return newTrainedEntityExtracter(model)
+	return newMaxentClassifier(weights, mapping, labels), nil
 }
 
-func defaultModel(tagging, classifying bool) *Model {
+func defaultModel(tagging, classifying, parsing bool) (*Model, error) {
 	var tagger *perceptronTagger
 	var classifier *entityExtracter
+	var parser *dependencyParser
+	var err error
 
 	if tagging || classifying {
-		tagger = newPerceptronTagger()
+		if tagger, err = builtinTagger(); err != nil {
+			return nil, err
+		}
 	}
 	if classifying {
-		classifier = newEntityExtracter()
+		if classifier, err = builtinExtracter(); err != nil {
+			return nil, err
+		}
+	}
+	if parsing {
+		if parser, err = builtinParser(); errors.Is(err, fs.ErrNotExist) {
+			return nil, errNoBuiltinParser
+		} else if err != nil {
+			return nil, err
+		}
 	}
 
 	return &Model{
@@ -171,5 +303,103 @@ func defaultModel(tagging, classifying bool) *Model {
 
 		tagger:    tagger,
 		extracter: classifier,
+		parser:    parser,
+	}, nil
+}
+
+// The built-in English model is expensive to decode, so we load each of its
+// parts at most once and share them between all Models and Documents that
+// use it (which is safe since they're never modified).
+var builtin struct {
+	sync.Mutex
+
+	tagger    *perceptronTagger
+	extracter *entityExtracter
+	parser    *dependencyParser
+	segmenter *punktSentenceTokenizer
+}
+
+// PreloadDefaultModel loads the built-in English model (and sentence
+// segmenter), which would otherwise happen lazily the first time that a
+// Document needs it.
+func PreloadDefaultModel() error {
+	if _, err := builtinTagger(); err != nil {
+		return err
+	}
+	if _, err := builtinExtracter(); err != nil {
+		return err
+	}
+	_, err := builtinSegmenter()
+	return err
+}
+
+// ReleaseDefaultModel drops prose's reference to the built-in English model,
+// allowing its memory to be reclaimed once no Document uses it. It'll be
+// reloaded the next time that it's needed.
+func ReleaseDefaultModel() {
+	builtin.Lock()
+	defer builtin.Unlock()
+
+	builtin.tagger = nil
+	builtin.extracter = nil
+	builtin.parser = nil
+	builtin.segmenter = nil
+}
+
+func builtinTagger() (*perceptronTagger, error) {
+	builtin.Lock()
+	defer builtin.Unlock()
+
+	if builtin.tagger == nil {
+		tagger, err := newPerceptronTagger()
+		if err != nil {
+			return nil, err
+		}
+		builtin.tagger = tagger
+	}
+	return builtin.tagger, nil
+}
+
+func builtinExtracter() (*entityExtracter, error) {
+	builtin.Lock()
+	defer builtin.Unlock()
+
+	if builtin.extracter == nil {
+		extracter, err := newEntityExtracter()
+		if err != nil {
+			return nil, err
+		}
+		builtin.extracter = extracter
+	}
+	return builtin.extracter, nil
+}
+
+// builtinParser loads the dependency parser from the built-in model's
+// "DependencyParser" assets (see `make parser`).
+func builtinParser() (*dependencyParser, error) {
+	builtin.Lock()
+	defer builtin.Unlock()
+
+	if builtin.parser == nil {
+		parser, err := loadParser(loadAsset)
+		if err != nil {
+			return nil, err
+		}
+		builtin.parser = parser
+	}
+	return builtin.parser, nil
+}
+
+func builtinSegmenter() (*punktSentenceTokenizer, error) {
+	builtin.Lock()
+	defer builtin.Unlock()
+
+	if builtin.segmenter == nil {
+		segmenter, err := newPunktSentenceTokenizer()
+		if err != nil {
+			return nil, err
+		}
+		builtin.segmenter = segmenter
 	}
+	return builtin.segmenter, nil
 }
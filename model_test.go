@@ -2,6 +2,7 @@ package prose
 
 import (
 	"embed"
+	"errors"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -64,4 +65,107 @@ func TestModelFromFS(t *testing.T) {
 	if ents[0].Label != "PRODUCT" {
 		t.Errorf("Expected to tab entity with PRODUCT, got = %v", ents[0].Label)
 	}
+
+	if ents[0].Start != 0 || ents[0].End != 10 {
+		t.Errorf("Expected entity offsets [0, 10), got = [%v, %v)", ents[0].Start, ents[0].End)
+	}
+}
+
+func TestLoadModelMissingAsset(t *testing.T) {
+	temp := t.TempDir()
+
+	model, err := LoadModelFromDisk(filepath.Join(testdata, "PRODUCT"))
+	if err != nil {
+		t.Fatalf("LoadModelFromDisk() unexpected error: %v", err)
+	}
+	if err = model.Write(temp); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+	if err = os.Remove(filepath.Join(temp, "Maxent", "labels.gob")); err != nil {
+		panic(err)
+	}
+
+	_, err = LoadModelFromDisk(temp)
+
+	var assetErr *AssetError
+	if !errors.As(err, &assetErr) {
+		t.Fatalf("LoadModelFromDisk() expected an AssetError, got = %v", err)
+	}
+	if assetErr.Asset != "Maxent/labels.gob" || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("LoadModelFromDisk() unexpected error: %v", err)
+	}
+
+	_, err = LoadModelFromFS("MISSING", embeddedModel)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("LoadModelFromFS() expected fs.ErrNotExist, got = %v", err)
+	}
+}
+
+func TestStageError(t *testing.T) {
+	model := &Model{Name: "empty"}
+
+	_, err := NewDocument("Windows 10 is an operating system", UsingModel(model))
+
+	var stageErr *StageError
+	if !errors.As(err, &stageErr) || stageErr.Stage != TaggerName {
+		t.Errorf("NewDocument() expected a tagger StageError, got = %v", err)
+	}
+}
+
+func TestDefaultModelShared(t *testing.T) {
+	if err := PreloadDefaultModel(); err != nil {
+		t.Fatalf("PreloadDefaultModel() unexpected error: %v", err)
+	}
+
+	first, err := defaultModel(true, true, false)
+	checkError(err)
+	second, err := defaultModel(true, true, false)
+	checkError(err)
+
+	if first.tagger != second.tagger || first.extracter != second.extracter {
+		t.Errorf("defaultModel() expected the built-in model to be shared")
+	}
+
+	ReleaseDefaultModel()
+
+	third, err := defaultModel(true, true, false)
+	checkError(err)
+	if third.tagger == first.tagger {
+		t.Errorf("ReleaseDefaultModel() expected the built-in model to be reloaded")
+	}
+}
+
+func TestModelWritePartial(t *testing.T) {
+	doc, err := NewDocument("Hello there.", WithExtraction(false))
+	checkError(err)
+	if err = doc.Model.Write(t.TempDir()); err == nil {
+		t.Errorf("Write() expected an error for a model without trained components")
+	}
+
+	extracter, err := newEntityExtracter()
+	checkError(err)
+
+	temp := filepath.Join(t.TempDir(), "ner")
+	model := &Model{Name: "ner", extracter: extracter}
+	if err = model.Write(temp); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+
+	entries, err := os.ReadDir(temp)
+	checkError(err)
+	if len(entries) != 1 || entries[0].Name() != "Maxent" {
+		t.Errorf("Write() expected only the extracter's assets, got = %v", entries)
+	}
+
+	loaded, err := LoadModelFromDisk(temp)
+	if err != nil {
+		t.Fatalf("LoadModelFromDisk() unexpected error: %v", err)
+	}
+	if loaded.extracter == nil {
+		t.Errorf("LoadModelFromDisk() expected an extracter, got = %+v", loaded)
+	}
+
+	if _, err = LoadModelFromDisk(t.TempDir()); err == nil {
+		t.Errorf("LoadModelFromDisk() expected an error for an empty folder")
+	}
 }
@@ -0,0 +1,554 @@
+package prose
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// An Ngram is a sequence of `n` consecutive words, along with the number of
+// times it occurs.
+type Ngram struct {
+	Words []string
+	Count int
+}
+
+// A Collocation is an n-gram whose words occur together more often than
+// chance would suggest (e.g., "New York").
+type Collocation struct {
+	Ngram
+
+	Score float64 // The collocation's association score.
+}
+
+// CollocationMeasure is a measure of association between the words of an
+// n-gram.
+type CollocationMeasure int
+
+const (
+	// PMI is pointwise mutual information: the (log2) ratio of an n-gram's
+	// frequency to the frequency expected if its words were independent.
+	PMI CollocationMeasure = iota
+	// LikelihoodRatio is Dunning's (1993) log-likelihood ratio.
+	LikelihoodRatio
+	// ChiSquare is Pearson's chi-square statistic.
+	ChiSquare
+	// TScore is Student's t statistic.
+	TScore
+)
+
+// NgramOpts controls n-gram counting:
+type NgramOpts struct {
+	// TagPatterns, if non-empty, restricts the counted n-grams to those whose
+	// POS tags match (at least) one of the patterns (see UsingTagPatterns).
+	TagPatterns []string
+	// Lowercase, if true, counts words case-insensitively.
+	Lowercase bool
+	// MaxNgrams bounds the number of distinct n-grams held in memory (see
+	// WithMaxNgrams).
+	MaxNgrams int
+}
+
+// An NgramOpt represents a setting that changes n-gram counting.
+type NgramOpt func(opts *NgramOpts)
+
+// UsingTagPatterns restricts counting to n-grams that match one of the given
+// POS tag patterns.
+//
+// Each pattern is a space-separated list of `n` regular expressions, one per
+// word, that must match the word's tag in its entirety. For example, the
+// patterns "JJ.? NN.*" and "NN.* NN.*" select adjective-noun and noun-noun
+// bigrams (Justeson and Katz, 1995). Tag patterns require that tagging be
+// enabled.
+func UsingTagPatterns(patterns ...string) NgramOpt {
+	return func(opts *NgramOpts) {
+		opts.TagPatterns = patterns
+	}
+}
+
+// WithLowercasing can enable or disable (the default) case-insensitive
+// counting.
+func WithLowercasing(include bool) NgramOpt {
+	return func(opts *NgramOpts) {
+		opts.Lowercase = include
+	}
+}
+
+// WithMaxNgrams limits the number of distinct n-grams (1<<20, by default)
+// that an NgramCounter holds in memory.
+//
+// Whenever the limit is exceeded, the least frequent n-grams are discarded,
+// so the counts of rare n-grams become approximate (lower bounds). Words
+// that no longer occur in any of the remaining n-grams are then forgotten
+// too, so the vocabulary (and memory use) stays proportional to the limit;
+// the counts of the words in the remaining n-grams are lower bounds as well.
+func WithMaxNgrams(n int) NgramOpt {
+	return func(opts *NgramOpts) {
+		opts.MaxNgrams = n
+	}
+}
+
+// An NgramCounter counts the n-grams in a collection of Documents (or
+// Sentences) and finds collocations among them.
+//
+// N-grams never cross sentence boundaries or punctuation.
+type NgramCounter struct {
+	n        int
+	opts     NgramOpts
+	patterns [][]*regexp.Regexp
+
+	ids   map[string]uint32
+	vocab []string
+	freq  []int    // The count of each word in `vocab`.
+	total int      // The total number of words.
+	free  []uint32 // The IDs of forgotten words, which may be reused.
+
+	// maxWords is the vocabulary size at which we forget the words that
+	// aren't part of any n-gram.
+	maxWords int
+
+	ngrams *ngramTable
+	// For trigrams, we also need the counts of adjacent pairs (w1 w2) and
+	// gapped pairs (w1 * w3).
+	pairs  *ngramTable
+	gapped *ngramTable
+}
+
+// NewNgramCounter creates an NgramCounter for n-grams of `n` words.
+func NewNgramCounter(n int, opts ...NgramOpt) (*NgramCounter, error) {
+	if n < 1 {
+		return nil, errors.New("prose: n-grams must have at least one word")
+	}
+
+	base := NgramOpts{MaxNgrams: 1 << 20}
+	for _, applyOpt := range opts {
+		applyOpt(&base)
+	}
+
+	counter := &NgramCounter{
+		n:        n,
+		opts:     base,
+		ids:      make(map[string]uint32),
+		ngrams:   newNgramTable(base.MaxNgrams),
+		maxWords: base.MaxNgrams,
+	}
+	if n == 3 {
+		counter.pairs = newNgramTable(base.MaxNgrams)
+		counter.gapped = newNgramTable(base.MaxNgrams)
+	}
+
+	for _, pattern := range base.TagPatterns {
+		fields := strings.Fields(pattern)
+		if len(fields) != n {
+			return nil, errors.New("prose: tag pattern '" + pattern + "' doesn't have n tags")
+		}
+
+		compiled := make([]*regexp.Regexp, n)
+		for i, field := range fields {
+			re, err := regexp.Compile("^(?:" + field + ")$")
+			if err != nil {
+				return nil, err
+			}
+			compiled[i] = re
+		}
+		counter.patterns = append(counter.patterns, compiled)
+	}
+
+	return counter, nil
+}
+
+// Add counts the n-grams in `doc`.
+func (c *NgramCounter) Add(doc *Document) {
+	for _, group := range doc.tokenGroups() {
+		c.addTokens(group)
+	}
+}
+
+// AddSentence counts the n-grams in `sent` (e.g., from a SentenceStream).
+func (c *NgramCounter) AddSentence(sent Sentence) {
+	c.addTokens(sent.tokens)
+}
+
+// Count returns the number of times the n-gram made up of `words` occurred.
+func (c *NgramCounter) Count(words ...string) int {
+	if len(words) != c.n {
+		return 0
+	}
+
+	ids := make([]uint32, len(words))
+	for i, word := range words {
+		if c.opts.Lowercase {
+			word = strings.ToLower(word)
+		}
+		id, found := c.ids[word]
+		if !found {
+			return 0
+		}
+		ids[i] = id
+	}
+
+	return c.ngrams.counts[ngramKey(ids...)]
+}
+
+// Ngrams returns the counted n-grams, from the most to least frequent.
+func (c *NgramCounter) Ngrams() []Ngram {
+	ngrams := make([]Ngram, 0, len(c.ngrams.counts))
+	for key, count := range c.ngrams.counts {
+		ngrams = append(ngrams, Ngram{Words: c.words(key), Count: count})
+	}
+
+	sort.Slice(ngrams, func(i, j int) bool {
+		if ngrams[i].Count != ngrams[j].Count {
+			return ngrams[i].Count > ngrams[j].Count
+		}
+		return strings.Join(ngrams[i].Words, " ") < strings.Join(ngrams[j].Words, " ")
+	})
+
+	return ngrams
+}
+
+// Collocations ranks the bigrams or trigrams that occurred at least
+// `minCount` times by `measure`, from the most to least strongly associated.
+//
+// The scores follow NLTK's BigramAssocMeasures and TrigramAssocMeasures,
+// using each word's overall frequency as its marginal count.
+func (c *NgramCounter) Collocations(measure CollocationMeasure, minCount int) ([]Collocation, error) {
+	if c.n != 2 && c.n != 3 {
+		return nil, errors.New("prose: collocations require bigrams or trigrams")
+	}
+
+	var collocations []Collocation
+	for key, count := range c.ngrams.counts {
+		if count < minCount {
+			continue
+		}
+
+		ids := ngramIDs(key)
+		marginals := []float64{}
+		for _, id := range ids {
+			marginals = append(marginals, float64(c.freq[id]))
+		}
+		if c.n == 3 {
+			// The pair tables are pruned independently of the trigrams, so a
+			// pair's count may be lower than that of a trigram containing it.
+			marginals = append(marginals,
+				math.Max(float64(c.pairs.counts[ngramKey(ids[0], ids[1])]), float64(count)),
+				math.Max(float64(c.gapped.counts[ngramKey(ids[0], ids[2])]), float64(count)),
+				math.Max(float64(c.pairs.counts[ngramKey(ids[1], ids[2])]), float64(count)))
+		}
+
+		collocations = append(collocations, Collocation{
+			Ngram: Ngram{Words: c.words(key), Count: count},
+			Score: associate(measure, float64(count), marginals, float64(c.total)),
+		})
+	}
+
+	sort.Slice(collocations, func(i, j int) bool {
+		a, b := collocations[i], collocations[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		} else if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return strings.Join(a.Words, " ") < strings.Join(b.Words, " ")
+	})
+
+	return collocations, nil
+}
+
+// addTokens counts the words and n-grams of a single sentence.
+func (c *NgramCounter) addTokens(tokens []*Token) {
+	// Punctuation splits the sentence into runs of words.
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && !isPunct(tokens[i].Text) {
+			continue
+		}
+		if i > start {
+			c.addRun(tokens[start:i])
+		}
+		start = i + 1
+	}
+}
+
+func (c *NgramCounter) addRun(tokens []*Token) {
+	ids := make([]uint32, len(tokens))
+	for i, tok := range tokens {
+		ids[i] = c.intern(tok.Text)
+		c.freq[ids[i]]++
+		c.total++
+	}
+
+	for i := 0; i+c.n <= len(ids); i++ {
+		if c.matches(tokens[i : i+c.n]) {
+			c.ngrams.add(ngramKey(ids[i : i+c.n]...))
+		}
+	}
+
+	if c.n == 3 {
+		for i := 0; i+1 < len(ids); i++ {
+			c.pairs.add(ngramKey(ids[i], ids[i+1]))
+			if i+2 < len(ids) {
+				c.gapped.add(ngramKey(ids[i], ids[i+2]))
+			}
+		}
+	}
+
+	if c.maxWords > 0 && len(c.ids) > c.maxWords {
+		c.pruneVocab()
+	}
+}
+
+// pruneVocab forgets the words that aren't part of any counted n-gram,
+// freeing their IDs for reuse.
+func (c *NgramCounter) pruneVocab() {
+	used := make([]bool, len(c.vocab))
+	for _, table := range []*ngramTable{c.ngrams, c.pairs, c.gapped} {
+		if table == nil {
+			continue
+		}
+		for key := range table.counts {
+			for _, id := range ngramIDs(key) {
+				used[id] = true
+			}
+		}
+	}
+
+	for word, id := range c.ids {
+		if !used[id] {
+			delete(c.ids, word)
+			c.vocab[id], c.freq[id] = "", 0
+			c.free = append(c.free, id)
+		}
+	}
+
+	// If most words are still in use, we wait for the vocabulary to grow
+	// further before trying again.
+	if c.maxWords < 2*len(c.ids) {
+		c.maxWords = 2 * len(c.ids)
+	}
+}
+
+// matches determines if `tokens` match one of the counter's tag patterns.
+func (c *NgramCounter) matches(tokens []*Token) bool {
+	if len(c.patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range c.patterns {
+		matched := true
+		for i, re := range pattern {
+			if !re.MatchString(tokens[i].Tag) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// intern returns the ID of `word`, adding it to the vocabulary if needed.
+func (c *NgramCounter) intern(word string) uint32 {
+	if c.opts.Lowercase {
+		word = strings.ToLower(word)
+	}
+	if id, found := c.ids[word]; found {
+		return id
+	}
+
+	if n := len(c.free); n > 0 {
+		id := c.free[n-1]
+		c.free = c.free[:n-1]
+		c.ids[word], c.vocab[id] = id, word
+		return id
+	}
+
+	id := uint32(len(c.vocab))
+	c.ids[word] = id
+	c.vocab = append(c.vocab, word)
+	c.freq = append(c.freq, 0)
+	return id
+}
+
+func (c *NgramCounter) words(key string) []string {
+	ids := ngramIDs(key)
+	words := make([]string, len(ids))
+	for i, id := range ids {
+		words[i] = c.vocab[id]
+	}
+	return words
+}
+
+// ngramKey packs a sequence of word IDs into a compact map key.
+func ngramKey(ids ...uint32) string {
+	buf := make([]byte, 4*len(ids))
+	for i, id := range ids {
+		binary.LittleEndian.PutUint32(buf[4*i:], id)
+	}
+	return string(buf)
+}
+
+func ngramIDs(key string) []uint32 {
+	ids := make([]uint32, len(key)/4)
+	for i := range ids {
+		ids[i] = binary.LittleEndian.Uint32([]byte(key[4*i : 4*i+4]))
+	}
+	return ids
+}
+
+// ngramTable counts n-grams, holding at most `limit` of them in memory.
+type ngramTable struct {
+	counts map[string]int
+	limit  int
+}
+
+func newNgramTable(limit int) *ngramTable {
+	return &ngramTable{counts: make(map[string]int), limit: limit}
+}
+
+func (t *ngramTable) add(key string) {
+	t.counts[key]++
+	if t.limit > 0 && len(t.counts) > t.limit {
+		t.prune(key)
+	}
+}
+
+// prune discards the least frequent n-grams (breaking ties by key, so that
+// pruning is deterministic) until the table is down to three quarters of its
+// limit, always keeping `keep` (the n-gram that was just added).
+//
+// Stopping at a low-water mark, rather than discarding every n-gram below
+// some count, keeps as many counts as possible while still amortizing the
+// cost of pruning over many additions.
+func (t *ngramTable) prune(keep string) {
+	keys := make([]string, 0, len(t.counts))
+	for key := range t.counts {
+		if key != keep {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if a, b := t.counts[keys[i]], t.counts[keys[j]]; a != b {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+
+	excess := min(len(t.counts)-t.limit*3/4, len(keys))
+	for _, key := range keys[:excess] {
+		delete(t.counts, key)
+	}
+}
+
+// smallValue avoids division by (and the logarithm of) zero.
+const smallValue = 1e-20
+
+// associate scores an n-gram that occurred `count` times by `measure`.
+//
+// For bigrams, `marginals` holds the frequencies of each word; for trigrams,
+// it also holds those of the pairs (w1 w2), (w1 * w3) and (w2 w3).
+func associate(measure CollocationMeasure, count float64, marginals []float64, total float64) float64 {
+	n := 2
+	if len(marginals) > 2 {
+		n = 3
+	}
+	words := marginals[:n]
+
+	switch measure {
+	case PMI:
+		return math.Log2(count*math.Pow(total, float64(n-1))) - math.Log2(product(words))
+	case TScore:
+		expected := product(words) / math.Pow(total, float64(n-1))
+		return (count - expected) / math.Sqrt(count+smallValue)
+	}
+
+	observed := contingency(count, marginals, total)
+	expected := expectedValues(observed, n)
+
+	score := 0.0
+	for i := range observed {
+		if measure == ChiSquare {
+			score += (observed[i] - expected[i]) * (observed[i] - expected[i]) /
+				(expected[i] + smallValue)
+		} else {
+			score += observed[i] * math.Log(observed[i]/(expected[i]+smallValue)+smallValue)
+		}
+	}
+	if measure == LikelihoodRatio {
+		score *= 2
+	}
+
+	return score
+}
+
+// contingency builds an n-gram's contingency table, in which bit `j` of a
+// cell's index is set if the cell counts n-grams whose `j`th word differs.
+//
+// Approximate (i.e., pruned) marginals can make the derived cells negative,
+// so we clamp them at zero.
+func contingency(count float64, marginals []float64, total float64) []float64 {
+	if len(marginals) == 2 {
+		nix, nxi := marginals[0], marginals[1]
+		noi, nio := nxi-count, nix-count
+		return []float64{count, noi, nio, total - count - noi - nio}
+	}
+
+	nixx, nxix, nxxi := marginals[0], marginals[1], marginals[2]
+	niix, nixi, nxii := marginals[3], marginals[4], marginals[5]
+
+	noii := nxii - count
+	nioi := nixi - count
+	niio := niix - count
+	nooi := nxxi - count - noii - nioi
+	noio := nxix - count - noii - niio
+	nioo := nixx - count - nioi - niio
+	nooo := total - count - noii - nioi - niio - nooi - noio - nioo
+
+	cells := []float64{count, noii, nioi, nooi, niio, noio, nioo, nooo}
+	for i := range cells {
+		cells[i] = math.Max(cells[i], 0)
+	}
+	return cells
+}
+
+// expectedValues computes the values expected in each cell of a contingency
+// table if the n-gram's words were independent.
+func expectedValues(observed []float64, n int) []float64 {
+	total := 0.0
+	for _, value := range observed {
+		total += value
+	}
+
+	expected := make([]float64, len(observed))
+	for i := range observed {
+		value := 1.0
+		for j := 0; j < n; j++ {
+			bit := 1 << j
+			margin := 0.0
+			for x := range observed {
+				if x&bit == i&bit {
+					margin += observed[x]
+				}
+			}
+			value *= margin
+		}
+		expected[i] = value / math.Pow(total, float64(n-1))
+	}
+
+	return expected
+}
+
+func product(values []float64) float64 {
+	result := 1.0
+	for _, value := range values {
+		result *= value
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+package prose
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNgramCounter(t *testing.T) {
+	doc, err := NewDocument("The cat sat. The cat ran, and the dog sat.",
+		WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	counter, err := NewNgramCounter(2, WithLowercasing(true))
+	checkError(err)
+	counter.Add(doc)
+
+	for _, test := range []struct {
+		words []string
+		count int
+	}{
+		{[]string{"the", "cat"}, 2},
+		{[]string{"The", "cat"}, 2},
+		{[]string{"cat", "sat"}, 1},
+		{[]string{"ran", "and"}, 0}, // Split by punctuation.
+		{[]string{"sat", "the"}, 0}, // Split by sentence boundaries.
+		{[]string{"the"}, 0},
+	} {
+		if observed := counter.Count(test.words...); observed != test.count {
+			t.Errorf("Count(%v) expected = %d, got = %d", test.words, test.count, observed)
+		}
+	}
+
+	ngrams := counter.Ngrams()
+	if len(ngrams) != 6 || strings.Join(ngrams[0].Words, " ") != "the cat" {
+		t.Errorf("Ngrams() unexpected result: %v", ngrams)
+	}
+}
+
+func TestNgramTagPatterns(t *testing.T) {
+	doc := makeTagged("The|DT new|JJ model|NN uses|VBZ neural|JJ networks|NNS " +
+		"and|CC machine|NN translation|NN .|.")
+
+	counter, err := NewNgramCounter(2, UsingTagPatterns("JJ.? NN.*", "NN.* NN.*"))
+	checkError(err)
+	counter.Add(doc)
+
+	observed := []string{}
+	for _, ngram := range counter.Ngrams() {
+		observed = append(observed, strings.Join(ngram.Words, " "))
+	}
+	expected := "machine translation,neural networks,new model"
+	if strings.Join(observed, ",") != expected {
+		t.Errorf("UsingTagPatterns() expected = %v, got = %v", expected, observed)
+	}
+
+	if _, err = NewNgramCounter(2, UsingTagPatterns("NN")); err == nil {
+		t.Errorf("NewNgramCounter() expected an error for a short pattern")
+	}
+	if _, err = NewNgramCounter(2, UsingTagPatterns("NN (")); err == nil {
+		t.Errorf("NewNgramCounter() expected an error for an invalid pattern")
+	}
+}
+
+func TestAssocMeasures(t *testing.T) {
+	// The worked examples from Manning and Schutze (1999), chapter 5, over a
+	// 14,307,668-word corpus (which are rounded).
+	total := 14307668.0
+	for _, test := range []struct {
+		measure   CollocationMeasure
+		count     float64
+		marginals []float64
+		expected  float64
+	}{
+		{PMI, 20, []float64{42, 20}, 18.38},                    // "Ayatollah Ruhollah"
+		{TScore, 8, []float64{15828, 4675}, 0.999932},          // "new companies"
+		{ChiSquare, 8, []float64{15828, 4675}, 1.55},           // "new companies"
+		{LikelihoodRatio, 150, []float64{12593, 932}, 1291.42}, // "most powerful"
+	} {
+		observed := associate(test.measure, test.count, test.marginals, total)
+		if math.Abs(observed-test.expected)/test.expected > 0.005 {
+			t.Errorf("associate(%v) expected = %v, got = %v", test.measure, test.expected, observed)
+		}
+	}
+
+	// Every cell of a trigram's contingency table is a disjoint count.
+	cells := contingency(2, []float64{10, 12, 8, 3, 4, 5}, 1000)
+	sum := 0.0
+	for _, cell := range cells {
+		if cell < 0 {
+			t.Errorf("contingency() unexpected negative cell: %v", cells)
+		}
+		sum += cell
+	}
+	if sum != 1000 {
+		t.Errorf("contingency() expected the cells to sum to 1000, got = %v", sum)
+	}
+}
+
+func TestCollocationsSherlock(t *testing.T) {
+	text := string(readDataFile(filepath.Join(testdata, "sherlock.txt")))
+	doc, err := NewDocument(text, WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	bigrams, err := NewNgramCounter(2, WithLowercasing(true))
+	checkError(err)
+	bigrams.Add(doc)
+
+	collocations, err := bigrams.Collocations(LikelihoodRatio, 5)
+	checkError(err)
+
+	found := false
+	for _, c := range collocations[:20] {
+		found = found || strings.Join(c.Words, " ") == "sherlock holmes"
+	}
+	if !found {
+		t.Errorf("Collocations() expected 'sherlock holmes' in the top 20: %v", collocations[:20])
+	}
+
+	// Bounding memory shouldn't change the counts of frequent bigrams.
+	bounded, err := NewNgramCounter(2, WithLowercasing(true), WithMaxNgrams(5000))
+	checkError(err)
+	bounded.Add(doc)
+
+	if n := len(bounded.ngrams.counts); n > 5000 {
+		t.Errorf("WithMaxNgrams() expected at most 5000 bigrams, got = %d", n)
+	}
+	if exact, approx := bigrams.Count("of", "the"), bounded.Count("of", "the"); approx > exact || approx < exact/2 {
+		t.Errorf("WithMaxNgrams() expected a count close to %d, got = %d", exact, approx)
+	}
+
+	trigrams, err := NewNgramCounter(3, WithLowercasing(true))
+	checkError(err)
+	for _, sent := range doc.Sentences() {
+		trigrams.AddSentence(sent)
+	}
+	for _, measure := range []CollocationMeasure{PMI, LikelihoodRatio, ChiSquare, TScore} {
+		results, err := trigrams.Collocations(measure, 3)
+		checkError(err)
+		if len(results) == 0 || math.IsNaN(results[0].Score) || math.IsInf(results[0].Score, 0) {
+			t.Errorf("Collocations(%v) unexpected trigrams: %v", measure, results)
+		}
+	}
+
+	unigrams, err := NewNgramCounter(1)
+	checkError(err)
+	if _, err = unigrams.Collocations(PMI, 1); err == nil {
+		t.Errorf("Collocations() expected an error for unigrams")
+	}
+}
+
+func TestNgramPruning(t *testing.T) {
+	table := newNgramTable(3)
+	for _, key := range []string{"a", "a", "b", "c", "d"} {
+		table.add(key)
+	}
+	if table.counts["d"] != 1 || table.counts["a"] != 2 || len(table.counts) > 3 {
+		t.Errorf("prune() unexpected counts: %v", table.counts)
+	}
+
+	// Pruning only discards enough singletons to get back to 3/4 of the
+	// limit, rather than all of them.
+	table = newNgramTable(100)
+	for i := 0; i < 1000; i++ {
+		table.add(ngramKey(uint32(i)))
+		if i%10 == 0 {
+			table.add(ngramKey(uint32(i)))
+		}
+	}
+	if n := len(table.counts); n < 75 || n > 100 {
+		t.Errorf("prune() expected between 75 and 100 n-grams, got = %d", n)
+	}
+	for i := 900; i < 1000; i += 10 {
+		if table.counts[ngramKey(uint32(i))] != 2 {
+			t.Errorf("prune() expected a count of 2 for %d, got = %d", i, table.counts[ngramKey(uint32(i))])
+		}
+	}
+
+	// Words that no remaining n-gram uses are forgotten, so the vocabulary
+	// stays bounded too.
+	words := []string{}
+	for i := 0; i < 5000; i++ {
+		words = append(words, fmt.Sprintf("w%d w%d new york ,", i, i+5000))
+	}
+	doc, err := NewDocument(strings.Join(words, " "), WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	bounded, err := NewNgramCounter(2, WithMaxNgrams(100))
+	checkError(err)
+	bounded.Add(doc)
+
+	if n := len(bounded.vocab); n > 1000 {
+		t.Errorf("WithMaxNgrams() expected a bounded vocabulary, got = %d words", n)
+	}
+	if n := bounded.Count("new", "york"); n != 5000 {
+		t.Errorf("Count(new york) expected = 5000, got = %d", n)
+	}
+	if n := bounded.freq[bounded.ids["york"]]; n != 5000 {
+		t.Errorf("freq(york) expected = 5000, got = %d", n)
+	}
+
+	// With a tight limit, the pair tables lose counts that their trigrams
+	// keep, which mustn't produce invalid scores.
+	doc, err = NewDocument(
+		"New York City is big. I love New York City. New York City never sleeps. "+
+			"Every city has a story. Some cities are small. The big apple is New York City.",
+		WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	trigrams, err := NewNgramCounter(3, WithLowercasing(true), WithMaxNgrams(4))
+	checkError(err)
+	trigrams.Add(doc)
+
+	if n := trigrams.Count("new", "york", "city"); n < 1 {
+		t.Errorf("WithMaxNgrams() expected 'new york city' to be counted, got = %d", n)
+	}
+	for _, measure := range []CollocationMeasure{PMI, LikelihoodRatio, ChiSquare, TScore} {
+		results, err := trigrams.Collocations(measure, 1)
+		checkError(err)
+		for _, c := range results {
+			if math.IsNaN(c.Score) || math.IsInf(c.Score, 0) {
+				t.Errorf("Collocations(%v) unexpected score: %v", measure, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package prose
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NumericComponent returns a pipeline stage that labels numeric expressions
+// as MONEY, PERCENT, QUANTITY or CARDINAL entities, setting each one's Value
+// to its normalized form:
+//
+//	"$1.2 million", "1.2 million dollars"  MONEY     1200000 USD
+//	"€5", "50 cents"                       MONEY     5 EUR, 0.5 USD
+//	"23%", "3.5 percent"                   PERCENT   23%, 3.5%
+//	"5 kg", "5kg", "five kilograms"        QUANTITY  5 kg
+//	"twenty-three", "1,200"                CARDINAL  23, 1200
+//
+// (see Entity.Amount). Numbers may be written with digits, words or both
+// ("one hundred and five", "3 million"). Number words are only recognized if
+// they're tagged "CD" or untagged, which avoids, e.g., the pronoun "one";
+// the only exception is the determiner (DT) "a" or "an" in "a million".
+//
+// Overlapping entities found by earlier stages (e.g., the DATE "3 weeks ago")
+// take precedence. A NumericComponent should run after the extracter (see
+// WithNumericEntities).
+func NumericComponent() Component {
+	return numericComponent{}
+}
+
+// WithNumericEntities can enable or disable (the default) a NumericComponent,
+// which runs right after the extracter (or at the end, if there's no
+// extracter).
+func WithNumericEntities(include bool) DocOpt {
+	return editPipeline(func(pipeline []Component) ([]Component, error) {
+		if !include {
+			return pipeline, nil
+		} else if idx, err := findComponent(pipeline, ExtracterName); err == nil {
+			return insertComponent(pipeline, idx+1, NumericComponent()), nil
+		}
+		return append(pipeline, NumericComponent()), nil
+	})
+}
+
+// Amount returns the number and unit of a MONEY ("USD"), PERCENT ("%"),
+// QUANTITY ("kg") or CARDINAL ("") entity.
+func (e Entity) Amount() (float64, string, bool) {
+	switch e.Label {
+	case "MONEY", "PERCENT", "QUANTITY", "CARDINAL":
+	default:
+		return 0, "", false
+	}
+
+	value, unit := e.Value, ""
+	if strings.HasSuffix(value, "%") {
+		value, unit = strings.TrimSuffix(value, "%"), "%"
+	} else if idx := strings.IndexByte(value, ' '); idx >= 0 {
+		value, unit = value[:idx], value[idx+1:]
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	return n, unit, err == nil
+}
+
+type numericComponent struct{}
+
+func (c numericComponent) Name() string { return NumericName }
+
+func (c numericComponent) Process(doc *Document) error {
+	var matched []Entity
+	for _, group := range doc.tokenGroups() {
+		matched = append(matched, findNumeric(doc.Text, group)...)
+	}
+
+	entities := mergeEntities(matched, doc.entities, ModelFirst)
+	relabel(doc.tokens, entities)
+	doc.SetEntities(entities)
+
+	return nil
+}
+
+var (
+	currencySymbols = map[string]string{
+		"$": "USD", "US$": "USD", "C$": "CAD", "A$": "AUD",
+		"€": "EUR", "£": "GBP", "¥": "JPY",
+	}
+	currencyCodes = map[string]bool{
+		"USD": true, "EUR": true, "GBP": true, "JPY": true, "CAD": true,
+		"AUD": true, "CHF": true, "CNY": true, "INR": true,
+	}
+	// currencyWords maps currency names to their codes and the power of ten
+	// of their value in that currency (e.g., a cent is 10^-2 USD).
+	currencyWords = map[string]struct {
+		code string
+		exp  int
+	}{
+		"dollar": {"USD", 0}, "dollars": {"USD", 0}, "buck": {"USD", 0}, "bucks": {"USD", 0},
+		"cent": {"USD", -2}, "cents": {"USD", -2},
+		"euro": {"EUR", 0}, "euros": {"EUR", 0},
+		"pence": {"GBP", -2}, "yen": {"JPY", 0},
+	}
+	// quantityUnits maps units to their symbols. Abbreviations may also be
+	// attached to their numbers (e.g., "5kg").
+	quantityUnits = map[string]string{
+		"mg": "mg", "milligram": "mg", "milligrams": "mg",
+		"g": "g", "gram": "g", "grams": "g",
+		"kg": "kg", "kilogram": "kg", "kilograms": "kg", "kilo": "kg", "kilos": "kg",
+		"lb": "lb", "lbs": "lb", "pound": "lb", "pounds": "lb",
+		"oz": "oz", "ounce": "oz", "ounces": "oz",
+		"ton": "t", "tons": "t", "tonne": "t", "tonnes": "t",
+		"mm": "mm", "millimeter": "mm", "millimeters": "mm", "millimetre": "mm", "millimetres": "mm",
+		"cm": "cm", "centimeter": "cm", "centimeters": "cm", "centimetre": "cm", "centimetres": "cm",
+		"m": "m", "meter": "m", "meters": "m", "metre": "m", "metres": "m",
+		"km": "km", "kilometer": "km", "kilometers": "km", "kilometre": "km", "kilometres": "km",
+		"inch": "in", "inches": "in",
+		"ft": "ft", "foot": "ft", "feet": "ft",
+		"yd": "yd", "yard": "yd", "yards": "yd",
+		"mi": "mi", "mile": "mi", "miles": "mi",
+		"ml": "ml", "milliliter": "ml", "milliliters": "ml", "millilitre": "ml", "millilitres": "ml",
+		"l": "l", "liter": "l", "liters": "l", "litre": "l", "litres": "l",
+		"gal": "gal", "gallon": "gal", "gallons": "gal",
+		"kb": "KB", "mb": "MB", "gb": "GB", "tb": "TB",
+		"mph": "mph", "kph": "km/h",
+	}
+	// numberScales maps scale words to their powers of ten, as moneyScales
+	// does for the abbreviations that may follow amounts of money ("$5m").
+	numberScales = map[string]int{
+		"thousand": 3, "million": 6, "billion": 9, "trillion": 12,
+	}
+	moneyScales = map[string]int{
+		"k": 3, "m": 6, "mm": 6, "b": 9, "bn": 9,
+	}
+	numberWords = map[string]int{
+		"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11,
+		"twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+		"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+		"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50, "sixty": 60,
+		"seventy": 70, "eighty": 80, "ninety": 90,
+	}
+
+	digitsRe       = regexp.MustCompile(`^(\d{1,3}(?:,\d{3})+|\d+)(\.\d+)?$`)
+	moneyDigitsRe  = regexp.MustCompile(`^((?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?)(k|m|mm|b|bn)?$`)
+	unitDigitsRe   = regexp.MustCompile(`^((?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?)([a-z]+)$`)
+	percentDigitRe = regexp.MustCompile(`^((?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?)%$`)
+	currencyAmtRe  = regexp.MustCompile(`^(US\$|C\$|A\$|[$€£¥])(.+)$`)
+)
+
+// A numericParser matches numeric expressions within a single sentence.
+type numericParser struct {
+	tokens []*Token
+	words  []string // The tokens' lowercased text.
+}
+
+// findNumeric finds the numeric expressions within a single sentence.
+func findNumeric(text string, tokens []*Token) []Entity {
+	p := numericParser{tokens: tokens, words: make([]string, len(tokens))}
+	for i, tok := range tokens {
+		p.words[i] = strings.ToLower(tok.Text)
+	}
+
+	var entities []Entity
+	for i := 0; i < len(tokens); {
+		label, value, end, ok := p.parse(i)
+		if !ok {
+			i++
+			continue
+		}
+		entities = append(entities, makeEntity(text, tokens[i:end], label, value))
+		i = end
+	}
+
+	return entities
+}
+
+// word returns the lowercased text of the i-th token, or "" if there's none.
+func (p *numericParser) word(i int) string {
+	if i < 0 || i >= len(p.words) {
+		return ""
+	}
+	return p.words[i]
+}
+
+// parse matches the longest numeric expression starting at the i-th token.
+func (p *numericParser) parse(i int) (string, string, int, bool) {
+	text := ""
+	if i < len(p.tokens) {
+		text = p.tokens[i].Text
+	}
+
+	// "$ 5", "USD 5", "€5"
+	code, ok := currencySymbols[text]
+	if !ok && currencyCodes[text] {
+		code, ok = text, true
+	}
+	if ok {
+		if n, end, ok := p.number(i+1, true); ok {
+			return "MONEY", formatNumber(n) + " " + code, end, true
+		}
+	} else if m := currencyAmtRe.FindStringSubmatch(text); m != nil {
+		if n, ok := parseDigits(strings.ToLower(m[2]), true); ok {
+			n, end := p.scale(n, i+1)
+			return "MONEY", formatNumber(n.value()) + " " + currencySymbols[m[1]], end, true
+		}
+	}
+
+	// "23%", "3.5kg"
+	if m := percentDigitRe.FindStringSubmatch(text); m != nil {
+		return "PERCENT", formatNumber(parseFloat(m[1])) + "%", i + 1, true
+	} else if m := unitDigitsRe.FindStringSubmatch(p.word(i)); m != nil {
+		if unit, ok := quantityUnits[m[2]]; ok {
+			return "QUANTITY", formatNumber(parseFloat(m[1])) + " " + unit, i + 1, true
+		}
+	}
+
+	n, end, ok := p.number(i, false)
+	if !ok {
+		return "", "", i, false
+	}
+
+	next := p.word(end)
+	if next == "%" || next == "percent" || next == "pct" {
+		return "PERCENT", formatNumber(n) + "%", end + 1, true
+	} else if next == "per" && p.word(end+1) == "cent" {
+		return "PERCENT", formatNumber(n) + "%", end + 2, true
+	} else if end < len(p.tokens) && currencyCodes[p.tokens[end].Text] {
+		return "MONEY", formatNumber(n) + " " + p.tokens[end].Text, end + 1, true
+	} else if currency, ok := currencyWords[next]; ok {
+		n = shiftNumber(n, currency.exp)
+		return "MONEY", formatNumber(n) + " " + currency.code, end + 1, true
+	} else if unit, ok := quantityUnits[next]; ok {
+		return "QUANTITY", formatNumber(n) + " " + unit, end + 1, true
+	}
+
+	return "CARDINAL", formatNumber(n), end, true
+}
+
+// A decimal is a number written as mantissa * 10^exp, which avoids rounding
+// errors in amounts such as "$1.2 million".
+type decimal struct {
+	mantissa float64
+	exp      int
+}
+
+func (d decimal) value() float64 {
+	return shiftNumber(d.mantissa, d.exp)
+}
+
+// number matches a number written with digits ("1,200" or "1.2 million"),
+// words ("one hundred and five") or, if `money` is true, an abbreviated scale
+// ("5m").
+func (p *numericParser) number(i int, money bool) (float64, int, bool) {
+	if n, ok := parseDigits(p.word(i), money); ok {
+		n, end := p.scale(n, i+1)
+		return n.value(), end, true
+	}
+	return p.numberWords(i)
+}
+
+// scale applies any scale words ("million") that follow a number written with
+// digits.
+func (p *numericParser) scale(n decimal, i int) (decimal, int) {
+	for {
+		if exp, ok := numberScales[p.word(i)]; ok {
+			n.exp += exp
+		} else if p.word(i) == "hundred" {
+			n.exp += 2
+		} else {
+			return n, i
+		}
+		i++
+	}
+}
+
+// numberWords matches a number written with words, such as "twenty-three",
+// "a hundred" or "three million two hundred and five".
+func (p *numericParser) numberWords(i int) (float64, int, bool) {
+	if i >= len(p.tokens) {
+		return 0, i, false
+	} else if tag := p.tokens[i].Tag; tag == "DT" {
+		if w := p.word(i); w != "a" && w != "an" {
+			return 0, i, false
+		}
+	} else if tag != "" && tag != "CD" {
+		return 0, i, false
+	}
+
+	s := numberState{}
+	j := i
+	if w := p.word(j); w == "a" || w == "an" {
+		// "a hundred", "a million"
+		if next := p.word(j + 1); next != "hundred" && numberScales[next] == 0 {
+			return 0, i, false
+		}
+		s.current, s.last = 1, unitWord
+		j++
+	}
+
+	for ; j < len(p.words); j++ {
+		next, ok := s, true
+		for _, part := range strings.Split(p.words[j], "-") {
+			if next, ok = next.add(part, p.word(j+1)); !ok {
+				break
+			}
+		}
+		if !ok {
+			break
+		}
+		s = next
+	}
+
+	// A trailing "and" isn't part of the number.
+	if s.last == andWord {
+		j--
+	}
+	if j == i || s.last == startWord || s.last == andWord {
+		return 0, i, false
+	}
+	return s.total + s.current, j, true
+}
+
+// The kinds of words in a number, as tracked by numberState.
+const (
+	startWord = iota
+	unitWord  // 1-9
+	teenWord  // 10-19
+	tensWord  // 20, 30, ..., 90
+	zeroWord
+	hundredWord
+	scaleWord
+	andWord
+)
+
+// numberState accumulates the value of a number written with words.
+type numberState struct {
+	total, current float64
+	last           int
+	scale          int // The last scale word's power of ten.
+}
+
+// add extends the number with `word` (followed by `next`), if the result is
+// well-formed.
+func (s numberState) add(word, next string) (numberState, bool) {
+	if n, ok := numberWords[word]; ok {
+		kind := unitWord
+		switch {
+		case n == 0:
+			kind = zeroWord
+		case n >= 20:
+			kind = tensWord
+		case n >= 10:
+			kind = teenWord
+		}
+
+		switch {
+		case kind == zeroWord && s.last != startWord:
+			return s, false
+		case kind == unitWord && s.last != startWord && s.last != tensWord &&
+			s.last != hundredWord && s.last != scaleWord && s.last != andWord:
+			return s, false
+		case kind != unitWord && s.last != startWord && s.last != hundredWord &&
+			s.last != scaleWord && s.last != andWord:
+			return s, false
+		}
+		s.current += float64(n)
+		s.last = kind
+		return s, true
+	}
+
+	switch {
+	case word == "hundred" && (s.last == unitWord || s.last == teenWord) && s.current < 100:
+		s.current *= 100
+		s.last = hundredWord
+		return s, true
+	case word == "and" && (s.last == hundredWord || s.last == scaleWord):
+		if _, ok := numberWords[strings.Split(next, "-")[0]]; ok {
+			s.last = andWord
+			return s, true
+		}
+	}
+
+	if exp, ok := numberScales[word]; ok && s.current > 0 && s.last != andWord &&
+		(s.scale == 0 || exp < s.scale) {
+		s.total += s.current * shiftNumber(1, exp)
+		s.current, s.last, s.scale = 0, scaleWord, exp
+		return s, true
+	}
+
+	return s, false
+}
+
+// parseDigits parses a number written with digits (e.g., "1,200.5"), along
+// with an abbreviated scale (e.g., "5m") if `money` is true.
+func parseDigits(w string, money bool) (decimal, bool) {
+	if !money {
+		if !digitsRe.MatchString(w) {
+			return decimal{}, false
+		}
+		return decimal{mantissa: parseFloat(w)}, true
+	}
+
+	m := moneyDigitsRe.FindStringSubmatch(w)
+	if m == nil {
+		return decimal{}, false
+	}
+	return decimal{mantissa: parseFloat(m[1]), exp: moneyScales[m[2]]}, true
+}
+
+// parseFloat parses a number that may contain thousands separators.
+func parseFloat(s string) float64 {
+	n, _ := strconv.ParseFloat(strings.Replace(s, ",", "", -1), 64)
+	return n
+}
+
+// shiftNumber returns n * 10^exp, rounded as if it had been written out in
+// full.
+func shiftNumber(n float64, exp int) float64 {
+	if exp == 0 {
+		return n
+	}
+	shifted, _ := strconv.ParseFloat(formatNumber(n)+"e"+strconv.Itoa(exp), 64)
+	return shifted
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
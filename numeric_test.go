@@ -0,0 +1,103 @@
+package prose
+
+import (
+	"reflect"
+	"testing"
+)
+
+func numericStrings(doc *Document) []string {
+	observed := []string{}
+	for _, ent := range doc.Entities() {
+		observed = append(observed, ent.Text+"/"+ent.Label+"/"+ent.Value)
+	}
+	return observed
+}
+
+func TestNumericEntities(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"The startup raised $1.2 million, or €900k, from US$5 investors.",
+			[]string{"$1.2 million/MONEY/1200000 USD", "€900k/MONEY/900000 EUR", "US$5/MONEY/5 USD"}},
+		{"It cost 50 cents, not 3 dollars or USD 4.99 or £3.50.",
+			[]string{"50 cents/MONEY/0.5 USD", "3 dollars/MONEY/3 USD", "USD 4.99/MONEY/4.99 USD", "£3.50/MONEY/3.5 GBP"}},
+		{"Prices rose 23% after falling 3.5 percent and 2 per cent.",
+			[]string{"23%/PERCENT/23%", "3.5 percent/PERCENT/3.5%", "2 per cent/PERCENT/2%"}},
+		{"The bag weighs 5 kg, or 5kg, about eleven pounds.",
+			[]string{"5 kg/QUANTITY/5 kg", "5kg/QUANTITY/5 kg", "eleven pounds/QUANTITY/11 lb"}},
+		{"There were twenty-three guests and 1,200 bottles.",
+			[]string{"twenty-three/CARDINAL/23", "1,200/CARDINAL/1200"}},
+		{"We sold one hundred and five copies to three million two hundred thousand readers.",
+			[]string{"one hundred and five/CARDINAL/105", "three million two hundred thousand/CARDINAL/3200000"}},
+		{"She gave a thousand reasons, and two and three make five.",
+			[]string{"a thousand/CARDINAL/1000", "two/CARDINAL/2", "three/CARDINAL/3", "five/CARDINAL/5"}},
+	}
+
+	for _, test := range tests {
+		doc, err := NewDocument(test.text,
+			WithTagging(false), WithExtraction(false), WithNumericEntities(true))
+		checkError(err)
+
+		if observed := numericStrings(doc); !reflect.DeepEqual(observed, test.expected) {
+			t.Errorf("%q: got %v, expected %v", test.text, observed, test.expected)
+		}
+	}
+}
+
+func TestNumericTags(t *testing.T) {
+	doc := makeTagged("The|DT one|NN I|PRP want|VBP costs|VBZ one|CD dollar|NN .|.")
+	checkError(NumericComponent().Process(doc))
+
+	expected := []string{"one dollar/MONEY/1 USD"}
+	if observed := numericStrings(doc); !reflect.DeepEqual(observed, expected) {
+		t.Errorf("Entities() = %v, expected %v", observed, expected)
+	}
+}
+
+func TestNumericDeterminers(t *testing.T) {
+	// "a" is a determiner that starts a number, but a determiner "one" isn't
+	// a number.
+	doc := makeTagged("A|DT million|CD people|NNS came|VBD ,|, one|DT by|IN one|DT .|.")
+	checkError(NumericComponent().Process(doc))
+
+	expected := []string{"A million/CARDINAL/1000000"}
+	if observed := numericStrings(doc); !reflect.DeepEqual(observed, expected) {
+		t.Errorf("Entities() = %v, expected %v", observed, expected)
+	}
+}
+
+func TestNumericPrecedence(t *testing.T) {
+	doc, err := NewDocument("It broke 3 weeks ago, after 2 days.",
+		WithTagging(false),
+		WithExtraction(false),
+		WithNumericEntities(true),
+		WithTemporalEntities(temporalReference))
+	checkError(err)
+
+	expected := []string{"3 weeks ago/DATE/2024-05-22", "2/CARDINAL/2"}
+	if observed := numericStrings(doc); !reflect.DeepEqual(observed, expected) {
+		t.Errorf("Entities() = %v, expected %v", observed, expected)
+	}
+}
+
+func TestEntityAmount(t *testing.T) {
+	for _, test := range []struct {
+		ent    Entity
+		amount float64
+		unit   string
+		ok     bool
+	}{
+		{Entity{Label: "MONEY", Value: "1200000 USD"}, 1200000, "USD", true},
+		{Entity{Label: "PERCENT", Value: "3.5%"}, 3.5, "%", true},
+		{Entity{Label: "QUANTITY", Value: "5 kg"}, 5, "kg", true},
+		{Entity{Label: "CARDINAL", Value: "23"}, 23, "", true},
+		{Entity{Label: "DATE", Value: "2024-06-12"}, 0, "", false},
+	} {
+		amount, unit, ok := test.ent.Amount()
+		if amount != test.amount || unit != test.unit || ok != test.ok {
+			t.Errorf("Amount(%v) = (%v, %q, %v), expected (%v, %q, %v)",
+				test.ent, amount, unit, ok, test.amount, test.unit, test.ok)
+		}
+	}
+}
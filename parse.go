@@ -0,0 +1,526 @@
+package prose
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// A ParsedSentence is a sentence annotated with its dependency tree, such as
+// one read from a CoNLL-U file.
+type ParsedSentence struct {
+	Words  []string // The sentence's words.
+	Tags   []string // The words' POS tags.
+	Heads  []int    // The index of each word's head, or -1 for the root.
+	Labels []string // The words' dependency labels (e.g., "nsubj").
+}
+
+// ReadCoNLLU reads dependency-annotated sentences in the CoNLL-U format (see
+// https://universaldependencies.org/format.html).
+//
+// Each word's tag is its language-specific (XPOS) tag, falling back to its
+// universal (UPOS) tag if the former is missing. Multi-word tokens and empty
+// nodes are skipped.
+func ReadCoNLLU(r io.Reader) ([]ParsedSentence, error) {
+	var sents []ParsedSentence
+	var sent ParsedSentence
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			if len(sent.Words) > 0 {
+				sents = append(sents, sent)
+			}
+			sent = ParsedSentence{}
+			continue
+		} else if strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if len(fields) != 10 {
+			return sents, fmt.Errorf("prose: CoNLL-U line %d: expected 10 fields, got %d", line, len(fields))
+		} else if strings.ContainsAny(fields[0], "-.") {
+			continue
+		}
+
+		head, err := strconv.Atoi(fields[6])
+		if err != nil {
+			return sents, fmt.Errorf("prose: CoNLL-U line %d: invalid head %q", line, fields[6])
+		}
+
+		tag := fields[4]
+		if tag == "_" {
+			tag = fields[3]
+		}
+
+		sent.Words = append(sent.Words, fields[1])
+		sent.Tags = append(sent.Tags, tag)
+		sent.Heads = append(sent.Heads, head-1)
+		sent.Labels = append(sent.Labels, fields[7])
+	}
+
+	if len(sent.Words) > 0 {
+		sents = append(sents, sent)
+	}
+	return sents, scanner.Err()
+}
+
+// UsingDependencies creates a dependency parser from annotated sentences,
+// making `iterations` passes over the data.
+//
+// The sentences may be read from a treebank using ReadCoNLLU.
+func UsingDependencies(sents []ParsedSentence, iterations int) DataSource {
+	return func(model *Model) {
+		for _, sent := range sents {
+			if len(sent.Tags) != len(sent.Words) || len(sent.Heads) != len(sent.Words) ||
+				len(sent.Labels) != len(sent.Words) {
+				model.err = errors.New("prose: mismatched dependency annotations")
+				return
+			}
+		}
+		model.parser = parserFromData(sents, iterations)
+	}
+}
+
+// DependencyScore reports the accuracy of a dependency parser.
+type DependencyScore struct {
+	UAS float64 // The fraction of words assigned the correct head.
+	LAS float64 // The fraction of words assigned the correct head and label.
+}
+
+// EvaluateDependencies parses `sents`, using their own POS tags, and
+// compares the results to their annotated dependencies.
+func (m *Model) EvaluateDependencies(sents []ParsedSentence) (DependencyScore, error) {
+	score := DependencyScore{}
+	if m.parser == nil {
+		return score, errNoParser
+	}
+
+	total, heads, labels := 0, 0, 0
+	for _, sent := range sents {
+		tokens := make([]*Token, len(sent.Words))
+		for i, word := range sent.Words {
+			tokens[i] = &Token{Text: word, Tag: sent.Tags[i]}
+		}
+		m.parser.parse(tokens)
+		for i, tok := range tokens {
+			total++
+			if tok.Head == sent.Heads[i] {
+				heads++
+				if tok.Dep == sent.Labels[i] {
+					labels++
+				}
+			}
+		}
+	}
+
+	if total > 0 {
+		score.UAS = float64(heads) / float64(total)
+		score.LAS = float64(labels) / float64(total)
+	}
+	return score, nil
+}
+
+var errNoParser = errors.New("the document's model has no dependency parser")
+
+var errNoBuiltinParser = errors.New(
+	"prose: the built-in model wasn't built with a dependency parser (see `make parser`)")
+
+// dependencyParser is a greedy, transition-based (arc-hybrid) dependency
+// parser that uses an averagedPerceptron to choose its transitions.
+//
+// See https://explosion.ai/blog/parsing-english-in-python for details.
+type dependencyParser struct {
+	model *averagedPerceptron
+}
+
+// The parser's transitions are either a shift or an arc (left or right)
+// followed by its label: "S", "L-nsubj", "R-dobj", etc.
+const shiftMove = "S"
+
+func newDependencyParser(weights map[string]map[string]float64, classes []string) *dependencyParser {
+	return &dependencyParser{model: newAveragedPerceptron(weights, nil, classes)}
+}
+
+// loadParser reads a dependencyParser from the "DependencyParser" folder of
+// a Model.
+func loadParser(load func(folder, name string, v interface{}) error) (*dependencyParser, error) {
+	var weights map[string]map[string]float64
+	var classes []string
+
+	if err := load("DependencyParser", "classes.gob", &classes); err != nil {
+		return nil, err
+	}
+	if err := load("DependencyParser", "weights.gob", &weights); err != nil {
+		return nil, err
+	}
+	return newDependencyParser(weights, classes), nil
+}
+
+// marshal saves the parser to disk.
+func (p *dependencyParser) marshal(path string) error {
+	folder := filepath.Join(path, "DependencyParser")
+	if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+		return assetError("save", "DependencyParser", err)
+	}
+	if err := saveDiskAsset(path, "DependencyParser", "classes.gob", p.model.classes); err != nil {
+		return err
+	}
+	return saveDiskAsset(path, "DependencyParser", "weights.gob", p.model.weights)
+}
+
+// parse assigns a head and dependency label to each of `tokens`, which make
+// up a single sentence.
+func (p *dependencyParser) parse(tokens []*Token) {
+	words, tags := parserContext(tokens)
+
+	state := newParseState(len(tokens))
+	for !state.done() {
+		scores := p.model.scores(state.features(words, tags))
+		state.apply(p.guessMove(scores, state))
+	}
+
+	for i, tok := range tokens {
+		tok.Head, tok.Dep = state.heads[i], state.labels[i]
+		if tok.Head == len(tokens) {
+			tok.Head = -1
+		}
+		if tok.Dep == "" {
+			tok.Dep = fallbackLabel
+		}
+	}
+}
+
+// bestMove returns the highest-scoring move for which `allowed` is true.
+func (p *dependencyParser) bestMove(scores map[string]float64, allowed func(string) bool) string {
+	best, bestScore := "", math.Inf(-1)
+	for _, move := range p.model.classes {
+		if score := scores[move]; score > bestScore && allowed(move) {
+			best, bestScore = move, score
+		}
+	}
+	return best
+}
+
+// guessMove returns the highest-scoring valid move in `state`.
+//
+// If none of the model's classes are valid (e.g., because it was trained
+// without any right arcs), we fall back to an unlabeled "dep" arc so that
+// parsing can always finish.
+func (p *dependencyParser) guessMove(scores map[string]float64, state *parseState) string {
+	if move := p.bestMove(scores, state.isValid); move != "" {
+		return move
+	}
+	for _, move := range []string{shiftMove, "L-" + fallbackLabel, "R-" + fallbackLabel} {
+		if state.isValid(move) {
+			return move
+		}
+	}
+	return ""
+}
+
+// fallbackLabel is the generic Universal Dependencies label, which we use
+// for arcs that the model itself can't produce (and for arcs that a treebank
+// leaves unlabeled, so that every parsed token has a Dep).
+const fallbackLabel = "dep"
+
+// parserFromData trains a dependencyParser on `sents`.
+//
+// We use a dynamic oracle (Goldberg and Nivre, 2012), which means that the
+// parser follows its own predictions during training and thereby learns how
+// to recover from its mistakes.
+func parserFromData(sents []ParsedSentence, iterations int) *dependencyParser {
+	parser := newDependencyParser(make(map[string]map[string]float64), []string{shiftMove})
+	for _, sent := range sents {
+		for _, label := range sent.Labels {
+			parser.model.addClass("L-" + label)
+			parser.model.addClass("R-" + label)
+		}
+	}
+
+	// We shuffle a copy of the data, using a fixed seed so that training is
+	// reproducible.
+	order := make([]ParsedSentence, len(sents))
+	copy(order, sents)
+	random := rand.New(rand.NewSource(0))
+
+	for iter := 0; iter < iterations; iter++ {
+		for _, sent := range order {
+			parser.trainSentence(sent)
+		}
+		random.Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	}
+	parser.model.averageWeights()
+
+	return parser
+}
+
+func (p *dependencyParser) trainSentence(sent ParsedSentence) {
+	n := len(sent.Words)
+
+	tokens := make([]*Token, n)
+	gold := make([]int, n)
+	for i, word := range sent.Words {
+		tokens[i] = &Token{Text: word, Tag: sent.Tags[i]}
+		if gold[i] = sent.Heads[i]; gold[i] < 0 {
+			gold[i] = n
+		}
+	}
+	words, tags := parserContext(tokens)
+
+	state := newParseState(n)
+	for !state.done() {
+		feats := state.features(words, tags)
+		scores := p.model.scores(feats)
+
+		guess := p.guessMove(scores, state)
+		costless := state.costlessMoves(gold, sent.Labels)
+		if truth := p.bestMove(scores, func(move string) bool {
+			return costless[move]
+		}); truth != "" {
+			p.model.update(truth, guess, feats)
+		}
+
+		state.apply(guess)
+	}
+}
+
+// parserContext returns the normalized words and the tags of `tokens`.
+func parserContext(tokens []*Token) ([]string, []string) {
+	words := make([]string, len(tokens))
+	tags := make([]string, len(tokens))
+	for i, tok := range tokens {
+		words[i], tags[i] = normalize(tok.Text), tok.Tag
+	}
+	return words, tags
+}
+
+// parseState is the configuration of an arc-hybrid transition system.
+//
+// The words of a sentence are numbered 0 to n-1, with an artificial root at
+// index n (i.e., at the end of the buffer).
+type parseState struct {
+	n      int
+	i      int   // the first word of the buffer
+	stack  []int // the partially-processed words
+	heads  []int
+	labels []string
+	lefts  [][]int // each word's children to its left
+	rights [][]int // each word's children to its right
+}
+
+func newParseState(n int) *parseState {
+	return &parseState{
+		n:      n,
+		heads:  make([]int, n+1),
+		labels: make([]string, n+1),
+		lefts:  make([][]int, n+1),
+		rights: make([][]int, n+1),
+	}
+}
+
+func (s *parseState) done() bool {
+	return s.i >= s.n && len(s.stack) == 0
+}
+
+// isValid determines if `move` can be applied to the current state.
+//
+// We only allow the root to have a single child.
+func (s *parseState) isValid(move string) bool {
+	if move == "" {
+		return false
+	}
+	switch move[0] {
+	case 'S':
+		return s.i < s.n
+	case 'L':
+		return len(s.stack) > 0 && (s.i < s.n || len(s.stack) == 1)
+	case 'R':
+		return len(s.stack) > 1
+	}
+	return false
+}
+
+// apply performs `move`, which must be valid.
+func (s *parseState) apply(move string) {
+	switch move[0] {
+	case 'S':
+		s.stack = append(s.stack, s.i)
+		s.i++
+	case 'L':
+		s.addArc(s.i, s.pop(), move[2:])
+	case 'R':
+		child := s.pop()
+		s.addArc(s.stack[len(s.stack)-1], child, move[2:])
+	}
+}
+
+func (s *parseState) pop() int {
+	top := s.stack[len(s.stack)-1]
+	s.stack = s.stack[:len(s.stack)-1]
+	return top
+}
+
+func (s *parseState) addArc(head, child int, label string) {
+	s.heads[child] = head
+	s.labels[child] = label
+	if child < head {
+		s.lefts[head] = append(s.lefts[head], child)
+	} else {
+		s.rights[head] = append(s.rights[head], child)
+	}
+}
+
+// costlessMoves returns the valid moves that don't rule out any of the gold
+// arcs that are still reachable from the current state.
+func (s *parseState) costlessMoves(gold []int, labels []string) map[string]bool {
+	moves := map[string]bool{}
+	if len(s.stack) == 0 {
+		moves[shiftMove] = s.isValid(shiftMove)
+		return moves
+	}
+
+	s0 := s.stack[len(s.stack)-1]
+	left, right := "L-"+labels[s0], "R-"+labels[s0]
+
+	shift := s.isValid(shiftMove)
+	leftOK, rightOK := s.isValid(left), s.isValid(right)
+	if shift && gold[s.i] == s0 {
+		return map[string]bool{shiftMove: true}
+	} else if leftOK && gold[s0] == s.i {
+		return map[string]bool{left: true}
+	}
+
+	if len(s.stack) > 1 && gold[s0] == s.stack[len(s.stack)-2] {
+		leftOK = false
+	}
+	if shift && s.hasGoldArc(s.i, s.stack, gold) {
+		shift = false
+	}
+	buffer := []int{}
+	for w := s.i + 1; w <= s.n; w++ {
+		buffer = append(buffer, w)
+	}
+	if s.hasGoldArc(s0, buffer, gold) {
+		leftOK, rightOK = false, false
+	}
+
+	moves[shiftMove], moves[left], moves[right] = shift, leftOK, rightOK
+	return moves
+}
+
+// hasGoldArc determines if there's a gold arc between `word` and any of
+// `others`.
+func (s *parseState) hasGoldArc(word int, others []int, gold []int) bool {
+	for _, other := range others {
+		if gold[word] == other || (other < s.n && gold[other] == word) {
+			return true
+		}
+	}
+	return false
+}
+
+// features extracts the parser's features from the current state.
+func (s *parseState) features(words, tags []string) map[string]float64 {
+	word := func(i int) string {
+		if i == s.n {
+			return "-ROOT-"
+		} else if i < 0 || i > s.n {
+			return ""
+		}
+		return words[i]
+	}
+	tag := func(i int) string {
+		if i == s.n {
+			return "-ROOT-"
+		} else if i < 0 || i > s.n {
+			return ""
+		}
+		return tags[i]
+	}
+	stack := func(depth int) int {
+		if depth < len(s.stack) {
+			return s.stack[len(s.stack)-1-depth]
+		}
+		return -1
+	}
+	children := func(i int, deps [][]int) (int, int, int) {
+		if i < 0 || i > s.n || len(deps[i]) == 0 {
+			return 0, -1, -1
+		} else if len(deps[i]) == 1 {
+			return 1, deps[i][0], -1
+		}
+		n := len(deps[i])
+		return n, deps[i][n-1], deps[i][n-2]
+	}
+
+	s0, s1, s2 := stack(0), stack(1), stack(2)
+	n0, n1, n2 := s.i, s.i+1, s.i+2
+
+	vn0b, n0b1, n0b2 := children(n0, s.lefts)
+	vs0b, s0b1, s0b2 := children(s0, s.lefts)
+	vs0f, s0f1, s0f2 := children(s0, s.rights)
+
+	dist := "0"
+	if s0 >= 0 {
+		dist = strconv.Itoa(min(n0-s0, 5))
+	}
+
+	feats := make(map[string]float64)
+	feats = add([]string{"bias"}, feats)
+
+	for _, w := range []int{n0, n1, n2, s0, s1, s2, n0b1, n0b2, s0b1, s0b2, s0f1, s0f2} {
+		if w := word(w); w != "" {
+			feats = add([]string{"w", w}, feats)
+		}
+		if t := tag(w); t != "" {
+			feats = add([]string{"t", t}, feats)
+		}
+	}
+	for i, w := range []int{n0, n1, n2, s0} {
+		feats = add([]string{"wt", strconv.Itoa(i), word(w), tag(w)}, feats)
+	}
+
+	feats = add([]string{"s0w n0w", word(s0), word(n0)}, feats)
+	feats = add([]string{"n0wt s0w", word(n0), tag(n0), word(s0)}, feats)
+	feats = add([]string{"n0wt s0t", word(n0), tag(n0), tag(s0)}, feats)
+	feats = add([]string{"n0t s0wt", tag(n0), word(s0), tag(s0)}, feats)
+	feats = add([]string{"n0w s0wt", word(n0), word(s0), tag(s0)}, feats)
+	feats = add([]string{"n0wt s0wt", word(n0), tag(n0), word(s0), tag(s0)}, feats)
+
+	for i, trigram := range [][]int{
+		{n0, n1, n2}, {s0, n0, n1}, {s0, s1, n0}, {s0, s0f1, n0},
+		{s0, n0, n0b1}, {s0, s0b1, s0b2}, {s0, s0f1, s0f2}, {n0, n0b1, n0b2},
+		{s0, s1, s2}} {
+		feats = add([]string{
+			"ttt", strconv.Itoa(i), tag(trigram[0]), tag(trigram[1]), tag(trigram[2])}, feats)
+	}
+
+	feats = add([]string{"s0w vs0f", word(s0), strconv.Itoa(vs0f)}, feats)
+	feats = add([]string{"s0w vs0b", word(s0), strconv.Itoa(vs0b)}, feats)
+	feats = add([]string{"n0w vn0b", word(n0), strconv.Itoa(vn0b)}, feats)
+	feats = add([]string{"s0t vs0f", tag(s0), strconv.Itoa(vs0f)}, feats)
+	feats = add([]string{"s0t vs0b", tag(s0), strconv.Itoa(vs0b)}, feats)
+	feats = add([]string{"n0t vn0b", tag(n0), strconv.Itoa(vn0b)}, feats)
+
+	feats = add([]string{"s0w d", word(s0), dist}, feats)
+	feats = add([]string{"n0w d", word(n0), dist}, feats)
+	feats = add([]string{"s0t d", tag(s0), dist}, feats)
+	feats = add([]string{"n0t d", tag(n0), dist}, feats)
+	feats = add([]string{"n0t s0t d", tag(n0), tag(s0), dist}, feats)
+	feats = add([]string{"n0w s0w d", word(n0), word(s0), dist}, feats)
+
+	return feats
+}
@@ -0,0 +1,169 @@
+package prose
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readSampleTreebank() []ParsedSentence {
+	file, err := os.Open(filepath.Join(testdata, "en_sample.conllu"))
+	checkError(err)
+	defer file.Close()
+
+	sents, err := ReadCoNLLU(file)
+	checkError(err)
+	return sents
+}
+
+func TestReadCoNLLU(t *testing.T) {
+	data := strings.Join([]string{
+		"# text = Don't go.",
+		"1-2\tDon't\t_\t_\t_\t_\t_\t_\t_\t_",
+		"1\tDo\tdo\tAUX\tVBP\t_\t3\taux\t_\t_",
+		"2\tn't\tnot\tPART\tRB\t_\t3\tadvmod\t_\t_",
+		"3\tgo\tgo\tVERB\t_\t_\t0\troot\t_\t_",
+		"3.1\tgone\t_\t_\t_\t_\t_\t_\t_\t_",
+		"4\t.\t.\tPUNCT\t.\t_\t3\tpunct\t_\t_",
+		"",
+	}, "\n")
+
+	sents, err := ReadCoNLLU(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadCoNLLU() unexpected error: %v", err)
+	}
+
+	expected := []ParsedSentence{{
+		Words:  []string{"Do", "n't", "go", "."},
+		Tags:   []string{"VBP", "RB", "VERB", "."},
+		Heads:  []int{2, 2, -1, 2},
+		Labels: []string{"aux", "advmod", "root", "punct"},
+	}}
+	if !reflect.DeepEqual(sents, expected) {
+		t.Errorf("ReadCoNLLU() expected = %v, got = %v", expected, sents)
+	}
+
+	if _, err = ReadCoNLLU(strings.NewReader("1\tgo\n")); err == nil {
+		t.Errorf("ReadCoNLLU() expected an error for a malformed line")
+	}
+}
+
+// splitSampleTreebank holds out every fourth sentence of the sample treebank
+// for evaluation.
+func splitSampleTreebank() (train, test []ParsedSentence) {
+	for i, sent := range readSampleTreebank() {
+		if i%4 == 3 {
+			test = append(test, sent)
+		} else {
+			train = append(train, sent)
+		}
+	}
+	return train, test
+}
+
+func TestDependencyParser(t *testing.T) {
+	train, test := splitSampleTreebank()
+
+	model, err := TrainModelFromData("parser", UsingDependencies(train, 10))
+	if err != nil {
+		t.Fatalf("TrainModelFromData() unexpected error: %v", err)
+	}
+
+	score, err := model.EvaluateDependencies(test)
+	if err != nil {
+		t.Fatalf("EvaluateDependencies() unexpected error: %v", err)
+	}
+	// The sample is tiny, so we only check that the parser generalizes well
+	// beyond chance to sentences that it hasn't seen.
+	if score.UAS < 0.6 || score.LAS < 0.5 || score.LAS > score.UAS {
+		t.Errorf("EvaluateDependencies() unexpected score: %+v", score)
+	}
+
+	doc := makeTagged("The|DT man|NN bought|VBD a|DT book|NN .|.")
+	doc.Model = model
+	checkError(ParserComponent().Process(doc))
+
+	heads, deps := []int{}, []string{}
+	for _, tok := range doc.Tokens() {
+		heads = append(heads, tok.Head)
+		deps = append(deps, tok.Dep)
+	}
+
+	expectedHeads := []int{1, 2, -1, 4, 2, 2}
+	expectedDeps := []string{"det", "nsubj", "root", "det", "obj", "punct"}
+	if !reflect.DeepEqual(heads, expectedHeads) || !reflect.DeepEqual(deps, expectedDeps) {
+		t.Errorf("ParserComponent() expected = %v %v, got = %v %v",
+			expectedHeads, expectedDeps, heads, deps)
+	}
+
+	temp := filepath.Join(testdata, "temp")
+	checkError(os.RemoveAll(temp))
+	checkError(model.Write(temp))
+	defer os.RemoveAll(temp)
+
+	loaded, err := LoadModelFromDisk(temp)
+	if err != nil {
+		t.Fatalf("LoadModelFromDisk() unexpected error: %v", err)
+	}
+	if reloaded, _ := loaded.EvaluateDependencies(test); reloaded != score {
+		t.Errorf("LoadModelFromDisk() expected score = %+v, got = %+v", score, reloaded)
+	}
+}
+
+func TestParserFallbackMove(t *testing.T) {
+	// A parser that has never seen an arc can only shift, so every arc must
+	// come from the fallback.
+	parser := newDependencyParser(make(map[string]map[string]float64), []string{shiftMove})
+
+	tokens := makeTagged("Cats|NNS eat|VBP fish|NN").tokens
+	parser.parse(tokens)
+
+	roots := 0
+	for _, tok := range tokens {
+		if tok.Dep != fallbackLabel {
+			t.Errorf("parse() expected Dep = %q, got = %q", fallbackLabel, tok.Dep)
+		}
+		if tok.Head == -1 {
+			roots++
+		}
+	}
+	if roots != 1 {
+		t.Errorf("parse() expected a single root, got = %d", roots)
+	}
+}
+
+func TestParserWithDefaultModel(t *testing.T) {
+	model, err := defaultModel(true, false, true)
+	if errors.Is(err, errNoBuiltinParser) {
+		t.Skip("the built-in model wasn't built with a dependency parser")
+	}
+	checkError(err)
+
+	// None of the sample's sentences are part of the training data.
+	score, err := model.EvaluateDependencies(readSampleTreebank())
+	checkError(err)
+	if score.UAS < 0.8 || score.LAS < 0.7 || score.LAS > score.UAS {
+		t.Errorf("EvaluateDependencies() unexpected score: %+v", score)
+	}
+
+	doc, err := NewDocument("The man bought a book.", WithParsing(true))
+	if err != nil {
+		t.Fatalf("NewDocument() unexpected error: %v", err)
+	}
+	for _, tok := range doc.Tokens() {
+		if !tok.Parsed() {
+			t.Errorf("WithParsing() expected %q to be parsed", tok.Text)
+		}
+	}
+}
+
+func TestParserWithoutModel(t *testing.T) {
+	doc := makeTagged("Cats|NNS eat|VBP fish|NN .|.")
+	doc.Model = &Model{}
+	if err := ParserComponent().Process(doc); !errors.Is(err, errNoParser) {
+		t.Errorf("ParserComponent() expected errNoParser, got = %v", err)
+	}
+}
@@ -0,0 +1,304 @@
+package prose
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A Component represents a single stage of the Document-creation pipeline.
+//
+// Components run in order over a Document, with each one seeing the results
+// of those that came before it. The built-in stages (segmentation,
+// tokenization, POS tagging, and named-entity extraction) are Components
+// themselves, which means that they may be reordered or replaced and that
+// custom stages (e.g., a lemmatizer or gazetteer) may be added between them.
+type Component interface {
+	// Name identifies the Component within a pipeline.
+	Name() string
+	// Process updates `doc` in place.
+	Process(doc *Document) error
+}
+
+// The names of the built-in Components.
+const (
+	SegmenterName  = "segmenter"
+	TokenizerName  = "tokenizer"
+	TaggerName     = "tagger"
+	LemmatizerName = "lemmatizer"
+	StemmerName    = "stemmer"
+	ParserName     = "parser"
+	ClassifierName = "classifier"
+	SentimentName  = "sentiment"
+	ExtracterName  = "extracter"
+	RulerName      = "ruler"
+	TemporalName   = "temporal"
+	NumericName    = "numeric"
+)
+
+// SegmenterComponent returns the built-in sentence segmentation stage.
+func SegmenterComponent() Component {
+	return segmenterComponent{}
+}
+
+// TokenizerComponent returns a tokenization stage that uses `tokenizer`.
+func TokenizerComponent(tokenizer Tokenizer) Component {
+	return tokenizerComponent{tokenizer: tokenizer}
+}
+
+// TaggerComponent returns the built-in POS tagging stage, which uses the
+// Document's Model.
+func TaggerComponent() Component {
+	return taggerComponent{}
+}
+
+// ParserComponent returns the built-in dependency parsing stage, which uses
+// the Document's Model to fill in each token's Head and Dep.
+//
+// It expects its input to have already been POS tagged.
+func ParserComponent() Component {
+	return parserComponent{}
+}
+
+// ExtracterComponent returns the built-in named-entity extraction stage,
+// which uses the Document's Model.
+//
+// It expects its input to have already been POS tagged.
+func ExtracterComponent() Component {
+	return extracterComponent{}
+}
+
+// UsingPipeline replaces the default pipeline with `components`, which are
+// run in the given order.
+//
+// For example, the default pipeline is equivalent to
+//
+//	prose.UsingPipeline(
+//	    prose.SegmenterComponent(),
+//	    prose.TokenizerComponent(prose.NewIterTokenizer()),
+//	    prose.TaggerComponent(),
+//	    prose.ExtracterComponent())
+func UsingPipeline(components ...Component) DocOpt {
+	return func(doc *Document, opts *DocOpts) {
+		opts.Pipeline = components
+	}
+}
+
+// WithComponent adds `component` to the end of the pipeline.
+func WithComponent(component Component) DocOpt {
+	return editPipeline(func(pipeline []Component) ([]Component, error) {
+		return append(pipeline, component), nil
+	})
+}
+
+// WithComponentBefore inserts `component` before the Component named `name`.
+func WithComponentBefore(name string, component Component) DocOpt {
+	return editPipeline(func(pipeline []Component) ([]Component, error) {
+		idx, err := findComponent(pipeline, name)
+		if err != nil {
+			return pipeline, err
+		}
+		return insertComponent(pipeline, idx, component), nil
+	})
+}
+
+// WithComponentAfter inserts `component` after the Component named `name`.
+func WithComponentAfter(name string, component Component) DocOpt {
+	return editPipeline(func(pipeline []Component) ([]Component, error) {
+		idx, err := findComponent(pipeline, name)
+		if err != nil {
+			return pipeline, err
+		}
+		return insertComponent(pipeline, idx+1, component), nil
+	})
+}
+
+// ReplacingComponent swaps the Component named `name` for `component`.
+func ReplacingComponent(name string, component Component) DocOpt {
+	return editPipeline(func(pipeline []Component) ([]Component, error) {
+		idx, err := findComponent(pipeline, name)
+		if err != nil {
+			return pipeline, err
+		}
+		pipeline[idx] = component
+		return pipeline, nil
+	})
+}
+
+type pipelineEdit func(pipeline []Component) ([]Component, error)
+
+func editPipeline(edit pipelineEdit) DocOpt {
+	return func(doc *Document, opts *DocOpts) {
+		opts.edits = append(opts.edits, edit)
+	}
+}
+
+func findComponent(pipeline []Component, name string) (int, error) {
+	for i, component := range pipeline {
+		if component.Name() == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("no component named %q in pipeline", name)
+}
+
+func insertComponent(pipeline []Component, idx int, component Component) []Component {
+	pipeline = append(pipeline, nil)
+	copy(pipeline[idx+1:], pipeline[idx:])
+	pipeline[idx] = component
+	return pipeline
+}
+
+// pipeline assembles the Components described by `opts`.
+func (opts *DocOpts) pipeline() ([]Component, error) {
+	var err error
+
+	pipeline := []Component{}
+	if opts.Pipeline != nil {
+		pipeline = append(pipeline, opts.Pipeline...)
+	} else {
+		if opts.Segment {
+			pipeline = append(pipeline, SegmenterComponent())
+		}
+		if opts.Tokenizer != nil {
+			pipeline = append(pipeline, TokenizerComponent(opts.Tokenizer))
+		}
+		if opts.Tag || opts.Extract || opts.Lemmatize || opts.Parse {
+			pipeline = append(pipeline, TaggerComponent())
+		}
+		if opts.Lemmatize {
+			pipeline = append(pipeline, LemmatizerComponent())
+		}
+		if opts.Stem {
+			pipeline = append(pipeline, StemmerComponent())
+		}
+		if opts.Parse {
+			pipeline = append(pipeline, ParserComponent())
+		}
+		if opts.Extract {
+			pipeline = append(pipeline, ExtracterComponent())
+		}
+		if opts.Classify {
+			pipeline = append(pipeline, ClassifierComponent())
+		}
+		if opts.Sentiment {
+			pipeline = append(pipeline, SentimentComponent())
+		}
+	}
+
+	for _, edit := range opts.edits {
+		if pipeline, err = edit(pipeline); err != nil {
+			return pipeline, err
+		}
+	}
+
+	return pipeline, nil
+}
+
+// requirements reports which parts of the default Model `pipeline` needs.
+func requirements(pipeline []Component) (tagging, classifying, parsing bool) {
+	for _, component := range pipeline {
+		switch component.(type) {
+		case taggerComponent:
+			tagging = true
+		case extracterComponent:
+			classifying = true
+		case parserComponent:
+			parsing = true
+		}
+	}
+	return tagging, classifying, parsing
+}
+
+type segmenterComponent struct{}
+
+func (segmenterComponent) Name() string { return SegmenterName }
+
+func (segmenterComponent) Process(doc *Document) error {
+	segmenter, err := builtinSegmenter()
+	if err != nil {
+		return err
+	}
+	doc.SetSentences(segmenter.segment(doc.Text))
+	return nil
+}
+
+type tokenizerComponent struct {
+	tokenizer Tokenizer
+}
+
+func (tokenizerComponent) Name() string { return TokenizerName }
+
+func (c tokenizerComponent) Process(doc *Document) error {
+	var tokens []*Token
+	var err error
+
+	if tokenizer, ok := c.tokenizer.(*iterTokenizer); ok {
+		tokens, err = tokenizer.tokenizeContext(doc.Context(), doc.Text)
+	} else {
+		tokens = c.tokenizer.Tokenize(doc.Text)
+	}
+
+	alignTokens(doc.Text, tokens)
+	doc.SetTokens(tokens)
+	return err
+}
+
+type taggerComponent struct{}
+
+func (taggerComponent) Name() string { return TaggerName }
+
+func (taggerComponent) Process(doc *Document) error {
+	if doc.Model == nil || doc.Model.tagger == nil {
+		return errors.New("the document's model has no tagger")
+	}
+	// We tag one sentence at a time so that context (i.e., the previous
+	// tags) doesn't leak across sentence boundaries.
+	for _, group := range doc.tokenGroups() {
+		if _, err := doc.Model.tagger.tagContext(doc.Context(), group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type parserComponent struct{}
+
+func (parserComponent) Name() string { return ParserName }
+
+func (parserComponent) Process(doc *Document) error {
+	if doc.Model == nil || doc.Model.parser == nil {
+		return errNoParser
+	}
+	for _, group := range doc.tokenGroups() {
+		if err := doc.Context().Err(); err != nil {
+			return err
+		}
+		doc.Model.parser.parse(group)
+	}
+	return nil
+}
+
+type extracterComponent struct{}
+
+func (extracterComponent) Name() string { return ExtracterName }
+
+func (extracterComponent) Process(doc *Document) error {
+	if doc.Model == nil || doc.Model.extracter == nil {
+		return errors.New("the document's model has no entity extracter")
+	}
+
+	var entities []Entity
+	var err error
+
+	for _, group := range doc.tokenGroups() {
+		var labeled []*Token
+		labeled, err = doc.Model.extracter.classifyContext(doc.Context(), group)
+		entities = append(entities, doc.Model.extracter.chunk(labeled)...)
+		if err != nil {
+			break
+		}
+	}
+
+	doc.SetEntities(entities)
+	return err
+}
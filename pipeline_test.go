@@ -0,0 +1,69 @@
+package prose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// upperCaser is a toy Component that labels all-caps tokens.
+type upperCaser struct{}
+
+func (upperCaser) Name() string { return "upper" }
+
+func (upperCaser) Process(doc *Document) error {
+	for _, tok := range doc.MutableTokens() {
+		if strings.ToUpper(tok.Text) == tok.Text {
+			tok.Label = "UPPER"
+		}
+	}
+	return nil
+}
+
+func TestPipelineCustomComponent(t *testing.T) {
+	doc, err := NewDocument(
+		"The NBA is popular.",
+		WithTagging(false),
+		WithExtraction(false),
+		WithComponentAfter(TokenizerName, upperCaser{}))
+	if err != nil {
+		panic(err)
+	}
+
+	labels := []string{}
+	for _, tok := range doc.Tokens() {
+		labels = append(labels, tok.Label)
+	}
+	if !reflect.DeepEqual(labels, []string{"", "UPPER", "", "", "UPPER"}) {
+		t.Errorf("PipelineCustomComponent() got = %v", labels)
+	}
+}
+
+func TestPipelineOrder(t *testing.T) {
+	text := "Hello World. My name is Jonas."
+
+	doc, err := NewDocument(text, UsingPipeline(
+		TokenizerComponent(NewIterTokenizer()),
+		SegmenterComponent()))
+	if err != nil {
+		panic(err)
+	}
+
+	expected := [][]string{{"Hello", "World", "."}, {"My", "name", "is", "Jonas", "."}}
+	for i, sent := range doc.Sentences() {
+		observed := []string{}
+		for _, tok := range sent.Tokens() {
+			observed = append(observed, tok.Text)
+		}
+		if !reflect.DeepEqual(observed, expected[i]) {
+			t.Errorf("PipelineOrder() got = %v", observed)
+		}
+	}
+}
+
+func TestPipelineMissingComponent(t *testing.T) {
+	_, err := NewDocument("Hello World.", WithComponentBefore("lemmatizer", upperCaser{}))
+	if err == nil {
+		t.Errorf("PipelineMissingComponent() expected an error")
+	}
+}
@@ -0,0 +1,239 @@
+package prose
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Readability holds a Document's text statistics, along with the scores that
+// common readability formulas assign it.
+//
+// The counts are based on the Document's tokens and sentences: its words are
+// the tokens that aren't punctuation, with clitics (e.g., "n't" and "'s")
+// counted as part of the preceding word.
+type Readability struct {
+	Characters     int // The number of letters and digits in words.
+	Syllables      int // The number of syllables (see Syllables).
+	Words          int // The number of words.
+	Sentences      int // The number of sentences.
+	ComplexWords   int // Words of three or more syllables, per Gunning Fog.
+	Polysyllables  int // Words of three or more syllables.
+	DifficultWords int // Words that aren't on the Dale-Chall list.
+
+	FleschReadingEase    float64 // 0-100; higher is easier.
+	FleschKincaid        float64 // A U.S. grade level.
+	GunningFog           float64 // A U.S. grade level.
+	SMOG                 float64 // A U.S. grade level.
+	ColemanLiau          float64 // A U.S. grade level.
+	AutomatedReadability float64 // A U.S. grade level.
+	DaleChall            float64 // 4.9 or lower is easily understood by 4th-graders.
+}
+
+// Readability computes `doc`'s text statistics and readability scores.
+//
+// Proper nouns are recognized by their POS tags, if tagging is enabled, or
+// by their capitalization otherwise.
+func (doc *Document) Readability() Readability {
+	r := Readability{}
+
+	for _, group := range doc.tokenGroups() {
+		words := 0
+		for i, tok := range group {
+			if isPunct(tok.Text) || isClitic(tok.Text) {
+				continue
+			}
+
+			syllables := Syllables(tok.Text)
+			r.Syllables += syllables
+			for _, c := range tok.Text {
+				if unicode.IsLetter(c) || unicode.IsDigit(c) {
+					r.Characters++
+				}
+			}
+
+			if syllables >= 3 {
+				r.Polysyllables++
+				if isComplexWord(tok, i == 0) {
+					r.ComplexWords++
+				}
+			}
+			if !isFamiliarWord(tok, i == 0) {
+				r.DifficultWords++
+			}
+
+			words++
+		}
+
+		if words > 0 {
+			r.Words += words
+			r.Sentences++
+		}
+	}
+
+	if r.Words == 0 {
+		return r
+	}
+
+	words, sents := float64(r.Words), float64(r.Sentences)
+	wordsPerSent := words / sents
+	sylPerWord := float64(r.Syllables) / words
+
+	r.FleschReadingEase = 206.835 - 1.015*wordsPerSent - 84.6*sylPerWord
+	r.FleschKincaid = 0.39*wordsPerSent + 11.8*sylPerWord - 15.59
+	r.GunningFog = 0.4 * (wordsPerSent + 100*float64(r.ComplexWords)/words)
+	r.SMOG = 1.043*math.Sqrt(float64(r.Polysyllables)*30/sents) + 3.1291
+	r.ColemanLiau = 0.0588*(100*float64(r.Characters)/words) - 0.296*(100*sents/words) - 15.8
+	r.AutomatedReadability = 4.71*float64(r.Characters)/words + 0.5*wordsPerSent - 21.43
+
+	difficult := 100 * float64(r.DifficultWords) / words
+	r.DaleChall = 0.1579*difficult + 0.0496*wordsPerSent
+	if difficult > 5 {
+		r.DaleChall += 3.6365
+	}
+
+	return r
+}
+
+// Syllables estimates the number of syllables in `word`.
+//
+// It counts groups of vowels, adjusting for common exceptions such as silent
+// e's ("make"), "-ed" endings ("jumped") and adjacent vowels that belong to
+// separate syllables ("lion"). Numbers count as a single syllable.
+func Syllables(word string) int {
+	letters := make([]rune, 0, len(word))
+	alnum := false
+	for _, r := range strings.ToLower(word) {
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+		alnum = alnum || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+
+	n := len(letters)
+	if !alnum {
+		return 0
+	} else if n <= 3 {
+		return 1
+	}
+
+	vowel := func(i int) bool {
+		switch letters[i] {
+		case 'a', 'e', 'i', 'o', 'u':
+			return true
+		case 'y':
+			return i > 0 && !isSyllableVowel(letters[i-1])
+		}
+		return false
+	}
+
+	count := 0
+	for i := range letters {
+		if vowel(i) && (i == 0 || !vowel(i-1)) {
+			count++
+		}
+	}
+
+	// Vowel pairs that are usually pronounced separately ("lion", "piano"),
+	// except in suffixes such as "-tion" and "-cial".
+	for i := 1; i+1 < n; i++ {
+		pair := string(letters[i : i+2])
+		switch pair {
+		case "ia", "io", "iu", "ua", "uo", "ii":
+			if !strings.ContainsRune("tscgq", letters[i-1]) {
+				count++
+			}
+		}
+	}
+
+	last, prev := letters[n-1], letters[n-2]
+	switch {
+	case last == 'e' && prev == 'l' && !isSyllableVowel(letters[n-3]):
+		// "table", "little"
+	case last == 'e' && !isSyllableVowel(prev):
+		// "make", "love"
+		count--
+	case last == 'd' && prev == 'e' && !strings.ContainsRune("td", letters[n-3]):
+		// "jumped", but not "wanted"
+		count--
+	case last == 's' && prev == 'e' && !isSyllableVowel(letters[n-3]) &&
+		!strings.ContainsRune("sxzcg", letters[n-3]) && !strings.HasSuffix(string(letters), "hes"):
+		// "makes", but not "boxes", "places" or "wishes"
+		count--
+	}
+
+	if count < 1 {
+		return 1
+	}
+	return count
+}
+
+func isSyllableVowel(r rune) bool {
+	return strings.ContainsRune("aeiouy", r)
+}
+
+// isClitic determines if `word` is a contraction's clitic (e.g., "n't").
+func isClitic(word string) bool {
+	return strings.HasPrefix(word, "'") || strings.HasPrefix(word, "’") ||
+		strings.EqualFold(word, "n't") || strings.EqualFold(word, "n’t")
+}
+
+// isProperNoun determines if `tok` is a proper noun, using its tag if it has
+// one (and its capitalization, outside of sentence-initial position, if not).
+func isProperNoun(tok *Token, initial bool) bool {
+	if tok.Tag != "" {
+		return tok.Tag == "NNP" || tok.Tag == "NNPS"
+	}
+	first := []rune(tok.Text)[0]
+	return !initial && unicode.IsUpper(first)
+}
+
+// isComplexWord determines if a word of three or more syllables counts as
+// "complex" for the Gunning Fog index, which excludes proper nouns, hyphenated
+// compounds, and words that only reach three syllables through the suffixes
+// "-es", "-ed" or "-ing".
+func isComplexWord(tok *Token, initial bool) bool {
+	if isProperNoun(tok, initial) || strings.Contains(tok.Text, "-") {
+		return false
+	}
+
+	word := strings.ToLower(tok.Text)
+	for _, suffix := range []string{"es", "ed", "ing"} {
+		if strings.HasSuffix(word, suffix) && Syllables(strings.TrimSuffix(word, suffix)) < 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// isFamiliarWord determines if `tok` is on the Dale-Chall list (or is a
+// regular inflection of a word that is). Proper nouns and numbers are
+// considered familiar.
+func isFamiliarWord(tok *Token, initial bool) bool {
+	word := strings.ToLower(tok.Text)
+	if !hasLetter(word) || isProperNoun(tok, initial) || daleChallWords[word] {
+		return true
+	} else if tok.Lemma != "" && daleChallWords[strings.ToLower(tok.Lemma)] {
+		return true
+	}
+
+	for _, suffix := range []string{"s", "es", "ed", "d", "ing", "er", "est", "ly"} {
+		if !strings.HasSuffix(word, suffix) {
+			continue
+		}
+		base := strings.TrimSuffix(word, suffix)
+		if daleChallWords[base] || daleChallWords[base+"e"] {
+			return true
+		}
+
+		// "stopped" -> "stop", "carried" -> "carry"
+		n := len(base)
+		if n > 2 && base[n-1] == base[n-2] && daleChallWords[base[:n-1]] {
+			return true
+		} else if n > 1 && base[n-1] == 'i' && daleChallWords[base[:n-1]+"y"] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package prose
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSyllables(t *testing.T) {
+	for word, expected := range map[string]int{
+		"the": 1, "cat": 1, "make": 1, "love": 1, "jumped": 1, "played": 1,
+		"makes": 1, "strength": 1, "table": 2, "little": 2, "wanted": 2,
+		"boxes": 2, "places": 2, "wishes": 2, "happy": 2, "lion": 2,
+		"people": 2, "beautiful": 3, "piano": 3, "nation": 2, "readability": 5,
+		"university": 5, "Compatibility": 6, "42": 1, "...": 0, "don't": 1,
+	} {
+		if observed := Syllables(word); observed != expected {
+			t.Errorf("Syllables(%q) expected = %d, got = %d", word, expected, observed)
+		}
+	}
+}
+
+func TestReadabilityCounts(t *testing.T) {
+	doc, err := NewDocument(
+		"The cat sat on the mat. It didn't like the extraordinary Mississippi weather.",
+		WithTagging(false),
+		WithExtraction(false))
+	checkError(err)
+
+	r := doc.Readability()
+	expected := Readability{
+		Characters:     60,
+		Syllables:      21,
+		Words:          13, // "n't" is part of "did"
+		Sentences:      2,
+		ComplexWords:   1, // "extraordinary" ("Mississippi" is a proper noun)
+		Polysyllables:  2,
+		DifficultWords: 1, // "extraordinary"
+	}
+
+	observed := r
+	observed.FleschReadingEase, observed.FleschKincaid, observed.GunningFog = 0, 0, 0
+	observed.SMOG, observed.ColemanLiau, observed.AutomatedReadability = 0, 0, 0
+	observed.DaleChall = 0
+	if observed != expected {
+		t.Errorf("Readability() expected = %+v, got = %+v", expected, observed)
+	}
+
+	for name, pair := range map[string][2]float64{
+		"FleschReadingEase":    {r.FleschReadingEase, 206.835 - 1.015*6.5 - 84.6*21/13},
+		"FleschKincaid":        {r.FleschKincaid, 0.39*6.5 + 11.8*21/13 - 15.59},
+		"GunningFog":           {r.GunningFog, 0.4 * (6.5 + 100.0/13)},
+		"SMOG":                 {r.SMOG, 1.043*math.Sqrt(2*30.0/2) + 3.1291},
+		"ColemanLiau":          {r.ColemanLiau, 0.0588*(6000.0/13) - 0.296*(200.0/13) - 15.8},
+		"AutomatedReadability": {r.AutomatedReadability, 4.71*60/13 + 0.5*6.5 - 21.43},
+		"DaleChall":            {r.DaleChall, 0.1579*(100.0/13) + 0.0496*6.5 + 3.6365},
+	} {
+		if math.Abs(pair[0]-pair[1]) > 1e-9 {
+			t.Errorf("Readability() %s expected = %v, got = %v", name, pair[1], pair[0])
+		}
+	}
+}
+
+func TestReadabilityOrdering(t *testing.T) {
+	easy, err := NewDocument("The dog ran home. He was glad to see his mother.",
+		WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	hard, err := NewDocument("Notwithstanding considerable methodological "+
+		"heterogeneity, the investigators determined that comprehensive "+
+		"pharmacological interventions substantially ameliorated cardiovascular "+
+		"complications.",
+		WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	e, h := easy.Readability(), hard.Readability()
+	if e.FleschReadingEase <= h.FleschReadingEase || e.FleschKincaid >= h.FleschKincaid ||
+		e.GunningFog >= h.GunningFog || e.DaleChall >= h.DaleChall {
+		t.Errorf("Readability() expected %+v to be easier than %+v", e, h)
+	}
+
+	empty, err := NewDocument("", WithTagging(false), WithExtraction(false))
+	checkError(err)
+	if r := empty.Readability(); r != (Readability{}) {
+		t.Errorf("Readability() expected zero values for an empty document, got = %+v", r)
+	}
+}
@@ -0,0 +1,258 @@
+package prose
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+)
+
+// RulerPrecedence determines how an EntityRuler resolves conflicts between
+// its own matches and the entities found by the NER model.
+type RulerPrecedence int
+
+const (
+	// RulerFirst keeps the ruler's matches, discarding any overlapping
+	// entities from the model.
+	RulerFirst RulerPrecedence = iota
+	// ModelFirst keeps the model's entities, discarding any overlapping
+	// matches from the ruler.
+	ModelFirst
+	// LongestSpan keeps the longest of any overlapping entities, preferring
+	// the ruler's matches in case of a tie.
+	LongestSpan
+)
+
+// RulerOpts controls how an EntityRuler matches and merges entities:
+type RulerOpts struct {
+	CaseSensitive bool            // If true, phrases must match exactly
+	Precedence    RulerPrecedence // How conflicts with the model are resolved
+	Tokenizer     Tokenizer       // The Tokenizer used to split phrases
+}
+
+// A RulerOpt represents a setting that changes an EntityRuler.
+type RulerOpt func(opts *RulerOpts)
+
+// WithCaseSensitivity can enable (the default) or disable case-sensitive
+// phrase matching.
+func WithCaseSensitivity(include bool) RulerOpt {
+	return func(opts *RulerOpts) {
+		opts.CaseSensitive = include
+	}
+}
+
+// UsingPrecedence specifies how conflicts with the model's entities are
+// resolved (RulerFirst, by default).
+func UsingPrecedence(precedence RulerPrecedence) RulerOpt {
+	return func(opts *RulerOpts) {
+		opts.Precedence = precedence
+	}
+}
+
+// UsingRulerTokenizer specifies the Tokenizer used to split phrases into
+// tokens, which should match the one used for Documents.
+func UsingRulerTokenizer(tokenizer Tokenizer) RulerOpt {
+	return func(opts *RulerOpts) {
+		opts.Tokenizer = tokenizer
+	}
+}
+
+// An EntityRuler is a pipeline Component that labels known phrases (e.g.,
+// product or customer names) as entities, combining them with those found
+// by the NER model.
+//
+// Phrases are stored in a trie of tokens and matched greedily (leftmost,
+// then longest) within each sentence. An EntityRuler should run after the
+// extracter (see UsingEntityRuler).
+type EntityRuler struct {
+	opts RulerOpts
+	root *rulerNode
+}
+
+type rulerNode struct {
+	children map[string]*rulerNode
+	label    string // The label of the phrase that ends here, if any.
+}
+
+// NewEntityRuler creates an EntityRuler without any phrases.
+func NewEntityRuler(opts ...RulerOpt) *EntityRuler {
+	base := RulerOpts{
+		CaseSensitive: true,
+		Precedence:    RulerFirst,
+		Tokenizer:     NewIterTokenizer(),
+	}
+	for _, applyOpt := range opts {
+		applyOpt(&base)
+	}
+	return &EntityRuler{opts: base, root: &rulerNode{}}
+}
+
+// UsingEntityRuler adds `ruler` to the pipeline, right after the extracter
+// (or at the end, if there's no extracter).
+func UsingEntityRuler(ruler *EntityRuler) DocOpt {
+	return editPipeline(func(pipeline []Component) ([]Component, error) {
+		if idx, err := findComponent(pipeline, ExtracterName); err == nil {
+			return insertComponent(pipeline, idx+1, ruler), nil
+		}
+		return append(pipeline, ruler), nil
+	})
+}
+
+// Add registers `phrases` as entities of type `label` (e.g., "PRODUCT").
+//
+// A phrase that was already registered takes on the new label.
+func (r *EntityRuler) Add(label string, phrases ...string) {
+	for _, phrase := range phrases {
+		node := r.root
+		for _, tok := range r.opts.Tokenizer.Tokenize(phrase) {
+			key := r.key(tok.Text)
+			if node.children == nil {
+				node.children = make(map[string]*rulerNode)
+			}
+			if node.children[key] == nil {
+				node.children[key] = &rulerNode{}
+			}
+			node = node.children[key]
+		}
+		if node != r.root {
+			node.label = label
+		}
+	}
+}
+
+// Load registers the phrases read from `reader` (one per line) as entities
+// of type `label`. Blank lines and lines starting with "#" are ignored.
+func (r *EntityRuler) Load(label string, reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			r.Add(label, line)
+		}
+	}
+	return scanner.Err()
+}
+
+// Name implements the Component interface.
+func (r *EntityRuler) Name() string { return RulerName }
+
+// Process implements the Component interface: it finds the ruler's phrases
+// in `doc` and merges them with its existing entities.
+func (r *EntityRuler) Process(doc *Document) error {
+	var matched []Entity
+	for _, group := range doc.tokenGroups() {
+		matched = append(matched, r.match(doc.Text, group)...)
+	}
+
+	entities := mergeEntities(matched, doc.entities, r.opts.Precedence)
+	relabel(doc.tokens, entities)
+	doc.SetEntities(entities)
+
+	return nil
+}
+
+func (r *EntityRuler) key(text string) string {
+	if r.opts.CaseSensitive {
+		return text
+	}
+	return strings.ToLower(text)
+}
+
+// match finds the ruler's phrases within a single sentence.
+func (r *EntityRuler) match(text string, tokens []*Token) []Entity {
+	var entities []Entity
+
+	for i := 0; i < len(tokens); {
+		node, end, label := r.root, -1, ""
+		for j := i; j < len(tokens); j++ {
+			node = node.children[r.key(tokens[j].Text)]
+			if node == nil {
+				break
+			} else if node.label != "" {
+				end, label = j+1, node.label
+			}
+		}
+
+		if end < 0 {
+			i++
+			continue
+		}
+
+		entities = append(entities, makeEntity(text, tokens[i:end], label, ""))
+		i = end
+	}
+
+	return entities
+}
+
+// mergeEntities combines the ruler's and model's entities, resolving any
+// overlaps according to `precedence`.
+func mergeEntities(ruler, model []Entity, precedence RulerPrecedence) []Entity {
+	type candidate struct {
+		Entity
+		fromRuler bool
+	}
+
+	candidates := make([]candidate, 0, len(ruler)+len(model))
+	for _, ent := range ruler {
+		candidates = append(candidates, candidate{ent, true})
+	}
+	for _, ent := range model {
+		candidates = append(candidates, candidate{ent, false})
+	}
+
+	// Candidates are considered in order of priority: later ones are kept
+	// only if they don't overlap anything kept before them.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		switch precedence {
+		case ModelFirst:
+			return !a.fromRuler && b.fromRuler
+		case LongestSpan:
+			if la, lb := a.RuneEnd-a.RuneStart, b.RuneEnd-b.RuneStart; la != lb {
+				return la > lb
+			}
+		}
+		return a.fromRuler && !b.fromRuler
+	})
+
+	var kept []Entity
+	for _, c := range candidates {
+		overlaps := false
+		for _, ent := range kept {
+			if c.Start < ent.End && ent.Start < c.End {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			kept = append(kept, c.Entity)
+		}
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Start < kept[j].Start
+	})
+	return kept
+}
+
+// relabel updates the tokens' BIO labels to match `entities`, which must be
+// sorted by position.
+func relabel(tokens []*Token, entities []Entity) {
+	idx := 0
+	for _, tok := range tokens {
+		for idx < len(entities) && entities[idx].End <= tok.Start {
+			idx++
+		}
+
+		if idx < len(entities) && entities[idx].Start <= tok.Start && tok.End <= entities[idx].End {
+			prefix := "I-"
+			if tok.Start == entities[idx].Start {
+				prefix = "B-"
+			}
+			tok.Label = prefix + entities[idx].Label
+		} else if tok.Label != "" {
+			tok.Label = "O"
+		}
+	}
+}
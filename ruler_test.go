@@ -0,0 +1,134 @@
+package prose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// makeLabeled creates a Document whose entities (and token labels) are given
+// by `labels`, in place of the NER model's.
+func makeLabeled(text string, labels ...string) *Document {
+	doc, err := NewDocument(text, WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	entities := []Entity{}
+	for i, tok := range doc.MutableTokens() {
+		tok.Label = labels[i]
+		if strings.HasPrefix(labels[i], "B-") {
+			entities = append(entities, coalesce([]*Token{tok}))
+		} else if strings.HasPrefix(labels[i], "I-") {
+			last := &entities[len(entities)-1]
+			last.Text += " " + tok.Text
+			last.End, last.RuneEnd = tok.End, tok.RuneEnd
+		}
+	}
+	doc.SetEntities(entities)
+
+	return doc
+}
+
+func entityStrings(entities []Entity) []string {
+	observed := []string{}
+	for _, ent := range entities {
+		observed = append(observed, ent.Text+"/"+ent.Label)
+	}
+	return observed
+}
+
+func TestEntityRulerPrecedence(t *testing.T) {
+	text := "Acme Cloud Storage is sold by Acme Corp in Paris ."
+	labels := []string{"B-ORG", "O", "O", "O", "O", "O", "B-ORG", "I-ORG", "O", "B-GPE", "O"}
+
+	ruler := func(precedence RulerPrecedence) *EntityRuler {
+		r := NewEntityRuler(UsingPrecedence(precedence))
+		r.Add("PRODUCT", "Acme Cloud Storage")
+		r.Add("CUSTOMER", "Corp")
+		return r
+	}
+
+	for _, test := range []struct {
+		precedence RulerPrecedence
+		expected   []string
+	}{
+		{RulerFirst, []string{"Acme Cloud Storage/PRODUCT", "Corp/CUSTOMER", "Paris/GPE"}},
+		{ModelFirst, []string{"Acme/ORG", "Acme Corp/ORG", "Paris/GPE"}},
+		{LongestSpan, []string{"Acme Cloud Storage/PRODUCT", "Acme Corp/ORG", "Paris/GPE"}},
+	} {
+		doc := makeLabeled(text, labels...)
+		checkError(ruler(test.precedence).Process(doc))
+
+		observed := entityStrings(doc.Entities())
+		if !reflect.DeepEqual(observed, test.expected) {
+			t.Errorf("EntityRuler(%v) expected = %v, got = %v", test.precedence, test.expected, observed)
+		}
+	}
+
+	doc := makeLabeled(text, labels...)
+	checkError(ruler(RulerFirst).Process(doc))
+
+	tokLabels := []string{}
+	for _, tok := range doc.Tokens() {
+		tokLabels = append(tokLabels, tok.Label)
+	}
+	expected := []string{
+		"B-PRODUCT", "I-PRODUCT", "I-PRODUCT", "O", "O", "O", "O",
+		"B-CUSTOMER", "O", "B-GPE", "O"}
+	if !reflect.DeepEqual(tokLabels, expected) {
+		t.Errorf("EntityRuler() expected labels = %v, got = %v", expected, tokLabels)
+	}
+}
+
+func TestEntityRulerCase(t *testing.T) {
+	sensitive := NewEntityRuler()
+	checkError(sensitive.Load("PRODUCT", strings.NewReader("# Products\nProse\n\nVale Server\n")))
+
+	insensitive := NewEntityRuler(WithCaseSensitivity(false))
+	insensitive.Add("PRODUCT", "Prose", "Vale Server")
+
+	text := "We use vale server and prose. Vale Server works with Prose."
+	for _, test := range []struct {
+		ruler    *EntityRuler
+		expected []string
+	}{
+		{sensitive, []string{"Vale Server/PRODUCT", "Prose/PRODUCT"}},
+		{insensitive, []string{
+			"vale server/PRODUCT", "prose/PRODUCT", "Vale Server/PRODUCT", "Prose/PRODUCT"}},
+	} {
+		doc, err := NewDocument(text,
+			WithTagging(false),
+			WithExtraction(false),
+			UsingEntityRuler(test.ruler))
+		checkError(err)
+
+		observed := entityStrings(doc.Entities())
+		if !reflect.DeepEqual(observed, test.expected) {
+			t.Errorf("EntityRuler() expected = %v, got = %v", test.expected, observed)
+		}
+
+		sents := doc.Sentences()
+		if len(sents) != 2 || len(sents[1].Entities()) != 2 {
+			t.Errorf("EntityRuler() expected entities in each sentence: %v", sents)
+		}
+	}
+}
+
+func TestUsingEntityRuler(t *testing.T) {
+	ruler := NewEntityRuler()
+	ruler.Add("PRODUCT", "Vale Server")
+
+	opts := &DocOpts{Segment: true, Tokenizer: NewIterTokenizer(), Tag: true, Extract: true}
+	UsingEntityRuler(ruler)(nil, opts)
+
+	pipeline, err := opts.pipeline()
+	checkError(err)
+
+	names := []string{}
+	for _, component := range pipeline {
+		names = append(names, component.Name())
+	}
+	expected := []string{SegmenterName, TokenizerName, TaggerName, ExtracterName, RulerName}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("UsingEntityRuler() expected = %v, got = %v", expected, names)
+	}
+}
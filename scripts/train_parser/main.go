@@ -0,0 +1,75 @@
+// Command train_parser trains the built-in model's dependency parser from a
+// CoNLL-U treebank, such as UD English EWT
+// (https://github.com/UniversalDependencies/UD_English-EWT), and writes it to
+// the model's "DependencyParser" folder.
+//
+//	go run ./scripts/train_parser -train en_ewt-ud-train.conllu -dev en_ewt-ud-dev.conllu
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/jdkato/prose/v3"
+)
+
+func readTreebank(path string) []prose.ParsedSentence {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	sents, err := prose.ReadCoNLLU(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return sents
+}
+
+func main() {
+	train := flag.String("train", "", "the training treebank (CoNLL-U)")
+	dev := flag.String("dev", "", "the held-out treebank (CoNLL-U) to report UAS/LAS on")
+	iterations := flag.Int("iterations", 15, "the number of passes over the training data")
+	out := flag.String("out", "model", "the model folder to write DependencyParser to")
+	flag.Parse()
+
+	if *train == "" {
+		log.Fatal("-train is required")
+	}
+
+	model, err := prose.TrainModelFromData("en-parser",
+		prose.UsingDependencies(readTreebank(*train), *iterations))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *dev != "" {
+		score, err := model.EvaluateDependencies(readTreebank(*dev))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("UAS: %.4f, LAS: %.4f\n", score.UAS, score.LAS)
+	}
+
+	temp, err := os.MkdirTemp("", "prose-parser")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(temp)
+
+	if err = model.Write(temp); err != nil {
+		log.Fatal(err)
+	}
+
+	dest := filepath.Join(*out, "DependencyParser")
+	if err = os.RemoveAll(dest); err != nil {
+		log.Fatal(err)
+	}
+	if err = os.Rename(filepath.Join(temp, "DependencyParser"), dest); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -23,6 +23,7 @@ package prose
 import (
 	"regexp"
 	"strings"
+	"unicode"
 
 	"gopkg.in/neurosnap/sentences.v1"
 	"gopkg.in/neurosnap/sentences.v1/data"
@@ -37,22 +38,34 @@ type punktSentenceTokenizer struct {
 
 // newPunktSentenceTokenizer creates a new PunktSentenceTokenizer and loads
 // its English model.
-func newPunktSentenceTokenizer() *punktSentenceTokenizer {
+func newPunktSentenceTokenizer() (*punktSentenceTokenizer, error) {
 	var pt punktSentenceTokenizer
 	var err error
 
 	pt.tokenizer, err = newSentenceTokenizer(nil)
-	checkError(err)
+	if err != nil {
+		return nil, err
+	}
 
-	return &pt
+	return &pt, nil
 }
 
 // segment splits text into sentences.
 func (p punktSentenceTokenizer) segment(text string) []Sentence {
 	tokens := p.tokenizer.Tokenize(text)
 	sents := make([]Sentence, len(tokens))
+
+	runes := runeCounter{text: text}
 	for i := range tokens {
-		sents[i] = Sentence{Text: strings.TrimSpace(tokens[i].Text)}
+		raw := tokens[i].Text
+		trimmed := strings.TrimLeftFunc(raw, unicode.IsSpace)
+
+		start := tokens[i].Start + len(raw) - len(trimmed)
+		end := start + len(strings.TrimRightFunc(trimmed, unicode.IsSpace))
+
+		sents[i] = Sentence{Text: text[start:end]}
+		sents[i].Start, sents[i].End = start, end
+		sents[i].RuneStart, sents[i].RuneEnd = runes.at(start), runes.at(end)
 	}
 	return sents
 }
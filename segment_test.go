@@ -62,7 +62,7 @@ func TestEnglishSmartQuotes(t *testing.T) {
 
 	for index, sent := range actual {
 		if sent.Text != expected[index] {
-			t.Fatalf("Actual: %s\nExpected: %s", sent, expected[index])
+			t.Fatalf("Actual: %s\nExpected: %s", sent.Text, expected[index])
 		}
 	}
 }
@@ -83,7 +83,7 @@ func TestEnglishCustomAbbrev(t *testing.T) {
 
 	for index, sent := range actual {
 		if sent.Text != expected[index] {
-			t.Fatalf("Actual: %s\nExpected: %s", sent, expected[index])
+			t.Fatalf("Actual: %s\nExpected: %s", sent.Text, expected[index])
 		}
 	}
 
@@ -102,7 +102,7 @@ func TestEnglishCustomAbbrev(t *testing.T) {
 
 	for index, sent := range actual {
 		if sent.Text != expected[index] {
-			t.Fatalf("Actual: %s\nExpected: %s", sent, expected[index])
+			t.Fatalf("Actual: %s\nExpected: %s", sent.Text, expected[index])
 		}
 	}
 }
@@ -124,7 +124,7 @@ func TestEnglishSupervisedAbbrev(t *testing.T) {
 
 	for index, sent := range actual {
 		if sent.Text != expected[index] {
-			t.Fatalf("Actual: %s\nExpected: %s", sent, expected[index])
+			t.Fatalf("Actual: %s\nExpected: %s", sent.Text, expected[index])
 		}
 	}
 }
@@ -145,7 +145,24 @@ func TestEnglishSemicolon(t *testing.T) {
 
 	for index, sent := range actual {
 		if sent.Text != expected[index] {
-			t.Fatalf("Actual: %s\nExpected: %s", sent, expected[index])
+			t.Fatalf("Actual: %s\nExpected: %s", sent.Text, expected[index])
+		}
+	}
+}
+
+func TestSentenceOffsets(t *testing.T) {
+	text := "  Caf\u00e9 au lait? It\u2019s \u00e9t\u00e9 here.\n\nGood.  "
+	doc, _ := makeSegmenter(text)
+
+	runes := []rune(text)
+	for _, sent := range doc.Sentences() {
+		if text[sent.Start:sent.End] != sent.Text {
+			t.Errorf("SentenceOffsets(bytes): got = %q, expected = %q",
+				text[sent.Start:sent.End], sent.Text)
+		}
+		if string(runes[sent.RuneStart:sent.RuneEnd]) != sent.Text {
+			t.Errorf("SentenceOffsets(runes): got = %q, expected = %q",
+				string(runes[sent.RuneStart:sent.RuneEnd]), sent.Text)
 		}
 	}
 }
@@ -165,7 +182,7 @@ func compareSentences(t *testing.T, actualText string, expected []string, test s
 	for index, sent := range actual {
 		if sent.Text != expected[index] {
 			t.Log(test)
-			t.Errorf("Actual: [%s] Expected: [%s]\n", sent, expected[index])
+			t.Errorf("Actual: [%s] Expected: [%s]\n", sent.Text, expected[index])
 			t.Log("===")
 			return false
 		}
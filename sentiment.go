@@ -0,0 +1,328 @@
+package prose
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Sentiment holds the sentiment scores of a text.
+//
+// Positive, Negative and Neutral are the proportions of the text that fall
+// into each category (and sum to 1), while Compound is a normalized,
+// weighted sum of its words' valences: it ranges from -1 (most negative) to
+// +1 (most positive).
+type Sentiment struct {
+	Positive float64
+	Negative float64
+	Neutral  float64
+	Compound float64
+}
+
+// Sentiment returns the sentiment of `doc`: the average of its sentences'
+// scores.
+func (doc *Document) Sentiment() Sentiment {
+	return doc.sentiment
+}
+
+// Sentiment returns the sentiment of `sent`.
+func (sent Sentence) Sentiment() Sentiment {
+	return sent.sentiment
+}
+
+// SentimentComponent returns the built-in sentiment analysis stage, which
+// scores the Document and each of its sentences.
+//
+// It's rule-based, in the style of VADER (Hutto and Gilbert, 2014), so it
+// doesn't use the Document's Model.
+func SentimentComponent() Component {
+	return sentimentComponent{}
+}
+
+type sentimentComponent struct{}
+
+func (sentimentComponent) Name() string { return SentimentName }
+
+func (sentimentComponent) Process(doc *Document) error {
+	groups := doc.tokenGroups()
+
+	total := Sentiment{}
+	for i, group := range groups {
+		score := scoreSentiment(group)
+		if i < len(doc.sentences) {
+			doc.sentences[i].sentiment = score
+		}
+		total.Positive += score.Positive
+		total.Negative += score.Negative
+		total.Neutral += score.Neutral
+		total.Compound += score.Compound
+	}
+
+	n := float64(len(groups))
+	doc.sentiment = Sentiment{
+		Positive: roundTo(total.Positive/n, 3),
+		Negative: roundTo(total.Negative/n, 3),
+		Neutral:  roundTo(total.Neutral/n, 3),
+		Compound: roundTo(total.Compound/n, 4),
+	}
+	return nil
+}
+
+const (
+	// sentimentBoost is the increase (or decrease) in valence caused by an
+	// intensifier such as "very" (or a dampener such as "slightly").
+	sentimentBoost = 0.293
+	// sentimentCapsBoost is the increase in valence caused by writing a word
+	// in all caps when the rest of the text isn't.
+	sentimentCapsBoost = 0.733
+	// sentimentNegation scales the valence of negated words.
+	sentimentNegation = -0.74
+	// sentimentAlpha controls how quickly the compound score approaches +/-1.
+	sentimentAlpha = 15
+)
+
+// scoreSentiment computes the Sentiment of the text made up of `tokens`.
+func scoreSentiment(tokens []*Token) Sentiment {
+	words := []string{}
+	exclamations, questions := 0, 0
+	for _, text := range mergeEmoticons(tokens) {
+		if _, found := emoticonValence[text]; found || !isPunct(text) {
+			words = append(words, text)
+		}
+		exclamations += strings.Count(text, "!")
+		questions += strings.Count(text, "?")
+	}
+
+	capsDiff := hasCapsDiff(words)
+	valences := make([]float64, len(words))
+	for i := range words {
+		valences[i] = wordValence(words, i, capsDiff)
+	}
+
+	// Contrastive conjunctions shift the emphasis to what follows them: "the
+	// food was good, but the service was awful."
+	for i, word := range words {
+		if strings.ToLower(word) == "but" {
+			for j := range valences {
+				if j < i {
+					valences[j] *= 0.5
+				} else if j > i {
+					valences[j] *= 1.5
+				}
+			}
+			break
+		}
+	}
+
+	return combineValences(valences, punctuationEmphasis(exclamations, questions))
+}
+
+// maxEmoticonTokens is the most tokens that the tokenizer splits an
+// emoticon into (e.g., ";-)" -> ";-", ")").
+const maxEmoticonTokens = 3
+
+// mergeEmoticons returns the text of `tokens`, re-joining adjacent tokens
+// that the tokenizer split apart but that make up an emoticon (e.g., ":"
+// and ")").
+func mergeEmoticons(tokens []*Token) []string {
+	texts := []string{}
+	for i := 0; i < len(tokens); i++ {
+		text, end := tokens[i].Text, i
+		joined := text
+		for j := i + 1; j < len(tokens) && j-i < maxEmoticonTokens; j++ {
+			if tokens[j].Start != tokens[j-1].End {
+				break
+			}
+			joined += tokens[j].Text
+			if _, found := emoticonValence[joined]; found {
+				text, end = joined, j
+			}
+		}
+		texts = append(texts, text)
+		i = end
+	}
+	return texts
+}
+
+// wordValence computes the valence of the word at index `i`, taking its
+// context (intensifiers, negations and capitalization) into account.
+func wordValence(words []string, i int, capsDiff bool) float64 {
+	word := words[i]
+	lower := strings.ToLower(word)
+
+	valence, found := emoticonValence[word]
+	if !found {
+		if _, booster := sentimentBoosters[lower]; booster {
+			return 0
+		} else if lower == "kind" && i+1 < len(words) && strings.ToLower(words[i+1]) == "of" {
+			return 0
+		} else if valence, found = sentimentLexicon[lower]; !found {
+			return 0
+		}
+	}
+
+	if capsDiff && isAllCaps(word) {
+		if valence > 0 {
+			valence += sentimentCapsBoost
+		} else {
+			valence -= sentimentCapsBoost
+		}
+	}
+
+	// We look at (up to) the three preceding words for intensifiers and
+	// negations, with their influence decreasing with distance. A preceding
+	// word with a valence of its own ends the window, since any modifiers
+	// before it apply to it instead: in "not good, bad", only "good" is
+	// negated.
+	for dist := 1; dist <= 3 && i-dist >= 0; dist++ {
+		prev := strings.ToLower(words[i-dist])
+		if _, found := sentimentLexicon[prev]; found {
+			break
+		}
+
+		scalar := boosterScalar(words[i-dist], valence, capsDiff)
+		if dist == 2 {
+			scalar *= 0.95
+		} else if dist == 3 {
+			scalar *= 0.9
+		}
+		valence += scalar
+
+		if isNegation(prev) {
+			if dist > 1 && strings.ToLower(words[i-dist]) == "never" &&
+				(strings.ToLower(words[i-dist+1]) == "so" || strings.ToLower(words[i-dist+1]) == "this") {
+				// "never so good"
+				valence *= 1.25
+			} else if !(dist > 1 && prev == "without" && strings.ToLower(words[i-dist+1]) == "doubt") {
+				valence *= sentimentNegation
+			}
+		}
+	}
+
+	// "least" (but not "at least") inverts the valence of what follows it.
+	if i > 0 && strings.ToLower(words[i-1]) == "least" {
+		if i == 1 || (strings.ToLower(words[i-2]) != "at" && strings.ToLower(words[i-2]) != "very") {
+			valence *= sentimentNegation
+		}
+	}
+
+	return valence
+}
+
+// boosterScalar returns the change in valence caused by `word` if it's an
+// intensifier or dampener.
+func boosterScalar(word string, valence float64, capsDiff bool) float64 {
+	scalar, found := sentimentBoosters[strings.ToLower(word)]
+	if !found {
+		return 0
+	}
+
+	if valence < 0 {
+		scalar = -scalar
+	}
+	if capsDiff && isAllCaps(word) {
+		if valence > 0 {
+			scalar += sentimentCapsBoost
+		} else {
+			scalar -= sentimentCapsBoost
+		}
+	}
+	return scalar
+}
+
+// punctuationEmphasis returns the increase in intensity caused by
+// exclamation and question marks.
+func punctuationEmphasis(exclamations, questions int) float64 {
+	emphasis := float64(min(exclamations, 4)) * 0.292
+	if questions > 1 {
+		if questions <= 3 {
+			emphasis += float64(questions) * 0.18
+		} else {
+			emphasis += 0.96
+		}
+	}
+	return emphasis
+}
+
+// combineValences computes the overall Sentiment of a text from the valences
+// of its words.
+func combineValences(valences []float64, emphasis float64) Sentiment {
+	if len(valences) == 0 {
+		return Sentiment{}
+	}
+
+	sum, pos, neg, neu := 0.0, 0.0, 0.0, 0.0
+	for _, v := range valences {
+		sum += v
+		if v > 0 {
+			pos += v + 1
+		} else if v < 0 {
+			neg += v - 1
+		} else {
+			neu++
+		}
+	}
+
+	if sum > 0 {
+		sum += emphasis
+	} else if sum < 0 {
+		sum -= emphasis
+	}
+	compound := math.Max(-1, math.Min(1, sum/math.Sqrt(sum*sum+sentimentAlpha)))
+
+	if pos > math.Abs(neg) {
+		pos += emphasis
+	} else if pos < math.Abs(neg) {
+		neg -= emphasis
+	}
+
+	total := pos + math.Abs(neg) + neu
+	return Sentiment{
+		Positive: roundTo(pos/total, 3),
+		Negative: roundTo(math.Abs(neg/total), 3),
+		Neutral:  roundTo(neu/total, 3),
+		Compound: roundTo(compound, 4),
+	}
+}
+
+// hasCapsDiff determines if some, but not all, of `words` are in all caps.
+func hasCapsDiff(words []string) bool {
+	caps := 0
+	for _, word := range words {
+		if isAllCaps(word) {
+			caps++
+		}
+	}
+	return caps > 0 && caps < len(words)
+}
+
+func isAllCaps(word string) bool {
+	hasUpper := false
+	for _, r := range word {
+		if unicode.IsLower(r) {
+			return false
+		} else if unicode.IsUpper(r) {
+			hasUpper = true
+		}
+	}
+	return hasUpper
+}
+
+func isNegation(word string) bool {
+	_, found := sentimentNegations[word]
+	return found || strings.HasSuffix(word, "n't")
+}
+
+func isPunct(word string) bool {
+	for _, r := range word {
+		if !unicode.IsPunct(r) && !unicode.IsSymbol(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func roundTo(x float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(x*scale) / scale
+}
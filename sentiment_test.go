@@ -0,0 +1,121 @@
+package prose
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func sentimentOf(text string) Sentiment {
+	doc, err := NewDocument(text,
+		WithSegmentation(false),
+		WithTagging(false),
+		WithExtraction(false),
+		WithSentiment(true))
+	checkError(err)
+	return doc.Sentiment()
+}
+
+func TestSentimentPolarity(t *testing.T) {
+	for _, test := range []struct {
+		text     string
+		positive bool
+	}{
+		{"This is a great product.", true},
+		{"The service was terrible.", false},
+		{"I don't like it.", false},
+		{"It isn't bad at all.", true},
+		{"Thanks for the help :-)", true},
+		{"My flight was delayed again :(", false},
+		{"The food was good, but the service was awful.", false},
+	} {
+		score := sentimentOf(test.text)
+		if (score.Compound > 0) != test.positive || score.Compound == 0 {
+			t.Errorf("Sentiment(%q) unexpected compound score: %+v", test.text, score)
+		}
+	}
+}
+
+func TestSentimentIntensity(t *testing.T) {
+	for _, test := range []struct{ weaker, stronger string }{
+		{"The movie was good.", "The movie was very good."},
+		{"The movie was slightly good.", "The movie was good."},
+		{"The movie was good.", "The movie was GOOD."},
+		{"The movie was good.", "The movie was good!!!"},
+		{"The movie was bad.", "The movie was extremely bad."},
+	} {
+		weak, strong := sentimentOf(test.weaker), sentimentOf(test.stronger)
+		if math.Abs(weak.Compound) >= math.Abs(strong.Compound) {
+			t.Errorf("Sentiment() expected %q (%v) to be stronger than %q (%v)",
+				test.stronger, strong.Compound, test.weaker, weak.Compound)
+		}
+	}
+}
+
+func TestSentimentEmoticons(t *testing.T) {
+	for _, text := range []string{"See you soon :)", "See you soon ;)", "See you soon ;-)"} {
+		if score := sentimentOf(text); score.Compound <= 0 {
+			t.Errorf("Sentiment(%q) expected a positive score, got = %+v", text, score)
+		}
+	}
+	if score := sentimentOf("See you soon : )"); score.Compound != 0 {
+		t.Errorf("Sentiment() expected a neutral score for split punctuation, got = %+v", score)
+	}
+}
+
+func TestSentimentNegationScope(t *testing.T) {
+	// "not" negates "good", but not the "bad" that follows it.
+	if score := sentimentOf("The food was not good, bad."); score.Compound >= 0 {
+		t.Errorf("Sentiment() expected a negative score, got = %+v", score)
+	}
+	if a, b := sentimentOf("It was not good bad."), sentimentOf("It was bad."); a.Compound >= b.Compound {
+		t.Errorf("Sentiment() expected %v to be more negative than %v", a.Compound, b.Compound)
+	}
+}
+
+func TestSentimentNeutral(t *testing.T) {
+	score := sentimentOf("The meeting is on Tuesday.")
+	if score.Compound != 0 || score.Neutral != 1 {
+		t.Errorf("Sentiment() expected a neutral score, got = %+v", score)
+	}
+
+	score = sentimentOf("I love it, the design is beautiful.")
+	total := score.Positive + score.Negative + score.Neutral
+	if math.Abs(total-1) > 0.01 || score.Compound > 1 {
+		t.Errorf("Sentiment() unexpected proportions: %+v", score)
+	}
+}
+
+func TestSentimentComponent(t *testing.T) {
+	doc, err := NewDocument("I love this city. The weather is awful.",
+		WithTagging(false),
+		WithExtraction(false),
+		WithSentiment(true))
+	checkError(err)
+
+	sents := doc.Sentences()
+	if len(sents) != 2 {
+		t.Fatalf("Sentences() expected 2 sentences, got = %d", len(sents))
+	}
+	if sents[0].Sentiment().Compound <= 0 || sents[1].Sentiment().Compound >= 0 {
+		t.Errorf("Sentiment() unexpected sentence scores: %+v, %+v",
+			sents[0].Sentiment(), sents[1].Sentiment())
+	}
+
+	avg := (sents[0].Sentiment().Compound + sents[1].Sentiment().Compound) / 2
+	if math.Abs(doc.Sentiment().Compound-avg) > 1e-3 {
+		t.Errorf("Sentiment() expected the document average %v, got = %v",
+			avg, doc.Sentiment().Compound)
+	}
+
+	data, err := json.Marshal(doc)
+	checkError(err)
+
+	var decoded Document
+	checkError(json.Unmarshal(data, &decoded))
+	if decoded.Sentiment() != doc.Sentiment() ||
+		decoded.Sentences()[1].Sentiment() != sents[1].Sentiment() {
+		t.Errorf("UnmarshalJSON() expected = %+v, got = %+v",
+			doc.Sentiment(), decoded.Sentiment())
+	}
+}
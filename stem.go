@@ -0,0 +1,352 @@
+package prose
+
+import "strings"
+
+// StemmerComponent returns the built-in stemming stage, which fills in each
+// token's Stem using Stem.
+func StemmerComponent() Component {
+	return stemmerComponent{}
+}
+
+type stemmerComponent struct{}
+
+func (stemmerComponent) Name() string { return StemmerName }
+
+func (stemmerComponent) Process(doc *Document) error {
+	for _, tok := range doc.tokens {
+		tok.Stem = Stem(tok.Text)
+	}
+	return nil
+}
+
+// Stem returns the stem of `word` according to the English Snowball
+// (Porter2) stemming algorithm.
+//
+// For example, "consolingly", "consoles" and "consolation" all stem to
+// "consol". Unlike a lemma, a stem isn't necessarily a word itself. See
+// https://snowballstem.org/algorithms/english/stemmer.html for details.
+func Stem(word string) string {
+	word = strings.ToLower(word)
+	if len(word) <= 2 {
+		return word
+	} else if stem, found := stemExceptions[word]; found {
+		return stem
+	}
+
+	s := newStemmer(word)
+	if len(s.w) <= 2 {
+		return string(s.w)
+	}
+
+	s.step0()
+	s.step1a()
+	if stemInvariants[string(s.w)] {
+		return string(s.w)
+	}
+	s.step1b()
+	s.step1c()
+	s.step2()
+	s.step3()
+	s.step4()
+	s.step5()
+
+	return strings.Replace(string(s.w), "Y", "y", -1)
+}
+
+// stemExceptions are the words that the Porter2 algorithm handles specially.
+var stemExceptions = map[string]string{
+	"skis": "ski", "skies": "sky", "dying": "die", "lying": "lie",
+	"tying": "tie", "idly": "idl", "gently": "gentl", "ugly": "ugli",
+	"early": "earli", "only": "onli", "singly": "singl", "sky": "sky",
+	"news": "news", "howe": "howe", "atlas": "atlas", "cosmos": "cosmos",
+	"bias": "bias", "andes": "andes",
+}
+
+// stemInvariants are left as-is once their plural suffix (if any) has been
+// removed.
+var stemInvariants = map[string]bool{
+	"inning": true, "outing": true, "canning": true, "herring": true,
+	"earring": true, "proceed": true, "exceed": true, "succeed": true,
+}
+
+// stemmer holds the state of a single run of the Porter2 algorithm.
+type stemmer struct {
+	w      []rune
+	r1, r2 int // the starting indices of the regions R1 and R2
+}
+
+func newStemmer(word string) *stemmer {
+	w := []rune(strings.TrimPrefix(word, "'"))
+
+	// We mark consonantal y's (i.e., those at the start of the word or after
+	// a vowel) as Y so that they aren't treated as vowels.
+	for i := range w {
+		if w[i] == 'y' && (i == 0 || isStemVowel(w[i-1])) {
+			w[i] = 'Y'
+		}
+	}
+
+	s := &stemmer{w: w}
+	s.r1 = s.region(0)
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(w), prefix) {
+			s.r1 = len(prefix)
+			break
+		}
+	}
+	s.r2 = s.region(s.r1)
+
+	return s
+}
+
+// region returns the index after the first non-vowel that follows a vowel,
+// starting at `start`, or the length of the word if there isn't one.
+func (s *stemmer) region(start int) int {
+	for i := start + 1; i < len(s.w); i++ {
+		if !isStemVowel(s.w[i]) && isStemVowel(s.w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(s.w)
+}
+
+func (s *stemmer) hasSuffix(suffix string) bool {
+	i := len(s.w) - len(suffix)
+	if i < 0 {
+		return false
+	}
+	// All of the suffixes that we look for are ASCII.
+	for j := 0; j < len(suffix); j++ {
+		if s.w[i+j] != rune(suffix[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// longestSuffix returns the longest of `suffixes` that the word ends with.
+func (s *stemmer) longestSuffix(suffixes ...string) string {
+	longest := ""
+	for _, suffix := range suffixes {
+		if len(suffix) > len(longest) && s.hasSuffix(suffix) {
+			longest = suffix
+		}
+	}
+	return longest
+}
+
+// inR1 and inR2 determine if `suffix` lies entirely within R1 or R2.
+func (s *stemmer) inR1(suffix string) bool {
+	return len(s.w)-len(suffix) >= s.r1
+}
+
+func (s *stemmer) inR2(suffix string) bool {
+	return len(s.w)-len(suffix) >= s.r2
+}
+
+// replace swaps the word's `suffix` for `with`.
+func (s *stemmer) replace(suffix, with string) {
+	s.w = append(s.w[:len(s.w)-len(suffix)], []rune(with)...)
+}
+
+// precededBy determines if the letter before `suffix` is one of `letters`.
+func (s *stemmer) precededBy(suffix, letters string) bool {
+	i := len(s.w) - len(suffix) - 1
+	return i >= 0 && strings.ContainsRune(letters, s.w[i])
+}
+
+// hasVowelBefore determines if the word contains a vowel before the letter at
+// index `end`.
+func (s *stemmer) hasVowelBefore(end int) bool {
+	for i := 0; i < end; i++ {
+		if isStemVowel(s.w[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsShortSyllable determines if the word ends in either a non-vowel, vowel,
+// non-vowel (other than w, x or Y) sequence or a vowel followed by a
+// non-vowel at the start of the word.
+func (s *stemmer) endsShortSyllable() bool {
+	n := len(s.w)
+	if n == 2 {
+		return isStemVowel(s.w[0]) && !isStemVowel(s.w[1])
+	}
+	return n > 2 &&
+		!isStemVowel(s.w[n-3]) && isStemVowel(s.w[n-2]) && !isStemVowel(s.w[n-1]) &&
+		!strings.ContainsRune("wxY", s.w[n-1])
+}
+
+func (s *stemmer) isShort() bool {
+	return s.r1 >= len(s.w) && s.endsShortSyllable()
+}
+
+func (s *stemmer) step0() {
+	if suffix := s.longestSuffix("'", "'s", "'s'"); suffix != "" {
+		s.replace(suffix, "")
+	}
+}
+
+func (s *stemmer) step1a() {
+	switch suffix := s.longestSuffix("sses", "ied", "ies", "us", "ss", "s"); suffix {
+	case "sses":
+		s.replace(suffix, "ss")
+	case "ied", "ies":
+		if len(s.w) > 4 {
+			s.replace(suffix, "i")
+		} else {
+			s.replace(suffix, "ie")
+		}
+	case "s":
+		// We only remove the "s" if there's a vowel before the letter
+		// preceding it (e.g., "gaps" -> "gap" but "gas" -> "gas").
+		if s.hasVowelBefore(len(s.w) - 2) {
+			s.replace(suffix, "")
+		}
+	}
+}
+
+func (s *stemmer) step1b() {
+	suffix := s.longestSuffix("eed", "eedly", "ed", "edly", "ing", "ingly")
+	switch suffix {
+	case "":
+		return
+	case "eed", "eedly":
+		if s.inR1(suffix) {
+			s.replace(suffix, "ee")
+		}
+		return
+	}
+
+	if !s.hasVowelBefore(len(s.w) - len(suffix)) {
+		return
+	}
+	s.replace(suffix, "")
+
+	n := len(s.w)
+	switch {
+	case s.hasSuffix("at"), s.hasSuffix("bl"), s.hasSuffix("iz"):
+		s.replace("", "e")
+	case n >= 2 && s.w[n-1] == s.w[n-2] && strings.ContainsRune("bdfgmnprt", s.w[n-1]):
+		s.replace(string(s.w[n-1]), "")
+	case s.isShort():
+		s.replace("", "e")
+	}
+}
+
+func (s *stemmer) step1c() {
+	n := len(s.w)
+	if n > 2 && (s.w[n-1] == 'y' || s.w[n-1] == 'Y') && !isStemVowel(s.w[n-2]) {
+		s.w[n-1] = 'i'
+	}
+}
+
+func (s *stemmer) step2() {
+	suffix := s.longestSuffix(
+		"tional", "enci", "anci", "abli", "entli", "izer", "ization",
+		"ational", "ation", "ator", "alism", "aliti", "alli", "fulness",
+		"ousli", "ousness", "iveness", "iviti", "biliti", "bli", "ogi",
+		"fulli", "lessli", "li")
+	if suffix == "" || !s.inR1(suffix) {
+		return
+	}
+
+	switch suffix {
+	case "tional":
+		s.replace(suffix, "tion")
+	case "enci":
+		s.replace(suffix, "ence")
+	case "anci":
+		s.replace(suffix, "ance")
+	case "abli":
+		s.replace(suffix, "able")
+	case "entli":
+		s.replace(suffix, "ent")
+	case "izer", "ization":
+		s.replace(suffix, "ize")
+	case "ational", "ation", "ator":
+		s.replace(suffix, "ate")
+	case "alism", "aliti", "alli":
+		s.replace(suffix, "al")
+	case "fulness":
+		s.replace(suffix, "ful")
+	case "ousli", "ousness":
+		s.replace(suffix, "ous")
+	case "iveness", "iviti":
+		s.replace(suffix, "ive")
+	case "biliti", "bli":
+		s.replace(suffix, "ble")
+	case "ogi":
+		if s.precededBy(suffix, "l") {
+			s.replace(suffix, "og")
+		}
+	case "fulli":
+		s.replace(suffix, "ful")
+	case "lessli":
+		s.replace(suffix, "less")
+	case "li":
+		if s.precededBy(suffix, "cdeghkmnrt") {
+			s.replace(suffix, "")
+		}
+	}
+}
+
+func (s *stemmer) step3() {
+	suffix := s.longestSuffix(
+		"tional", "ational", "alize", "icate", "iciti", "ical", "ful", "ness",
+		"ative")
+	if suffix == "" || !s.inR1(suffix) {
+		return
+	}
+
+	switch suffix {
+	case "tional":
+		s.replace(suffix, "tion")
+	case "ational":
+		s.replace(suffix, "ate")
+	case "alize":
+		s.replace(suffix, "al")
+	case "icate", "iciti", "ical":
+		s.replace(suffix, "ic")
+	case "ful", "ness":
+		s.replace(suffix, "")
+	case "ative":
+		if s.inR2(suffix) {
+			s.replace(suffix, "")
+		}
+	}
+}
+
+func (s *stemmer) step4() {
+	suffix := s.longestSuffix(
+		"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement",
+		"ment", "ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion")
+	if suffix == "" || !s.inR2(suffix) {
+		return
+	}
+
+	if suffix != "ion" || s.precededBy(suffix, "st") {
+		s.replace(suffix, "")
+	}
+}
+
+func (s *stemmer) step5() {
+	if s.hasSuffix("e") {
+		if s.inR2("e") {
+			s.replace("e", "")
+		} else if s.inR1("e") {
+			s.replace("e", "")
+			if s.endsShortSyllable() {
+				s.replace("", "e")
+			}
+		}
+	} else if s.hasSuffix("l") && s.inR2("l") && s.precededBy("l", "l") {
+		s.replace("l", "")
+	}
+}
+
+func isStemVowel(r rune) bool {
+	return strings.ContainsRune("aeiouy", r)
+}
@@ -0,0 +1,48 @@
+package prose
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStemReference(t *testing.T) {
+	data := readDataFile(filepath.Join(testdata, "porter2_en.txt"))
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if stem := Stem(fields[0]); stem != fields[1] {
+			t.Errorf("Stem(%q) expected = %q, got = %q", fields[0], fields[1], stem)
+		}
+	}
+}
+
+func TestStemmerComponent(t *testing.T) {
+	doc, err := NewDocument("The Knights were kneeling.",
+		WithTagging(false),
+		WithExtraction(false),
+		WithStemming(true))
+	checkError(err)
+
+	expected := []string{"the", "knight", "were", "kneel", "."}
+	observed := []string{}
+	for _, tok := range doc.Tokens() {
+		observed = append(observed, tok.Stem)
+	}
+
+	if !reflect.DeepEqual(observed, expected) {
+		t.Errorf("StemmerComponent() expected = %v, got = %v", expected, observed)
+	}
+}
+
+func BenchmarkStem(b *testing.B) {
+	words := strings.Fields("consolingly conspirators knackeries generously communication")
+	for n := 0; n < b.N; n++ {
+		for _, word := range words {
+			Stem(word)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package prose
+
+import "strings"
+
+// EnglishStopwords returns a list of common English function words (e.g.,
+// "the", "of" and "which") that carry little meaning on their own.
+//
+// The returned slice is a copy, so it may be extended or trimmed as needed.
+func EnglishStopwords() []string {
+	words := make([]string, len(englishStopwords))
+	copy(words, englishStopwords)
+	return words
+}
+
+var englishStopwords = []string{
+	"a", "about", "above", "after", "again", "against", "all", "also", "am",
+	"an", "and", "any", "are", "as", "at", "be", "because", "been", "before",
+	"being", "below", "between", "both", "but", "by", "can", "could", "did",
+	"do", "does", "doing", "down", "during", "each", "either", "etc", "few",
+	"for", "from", "further", "had", "has", "have", "having", "he", "her",
+	"here", "hers", "herself", "him", "himself", "his", "how", "however", "i",
+	"if", "in", "into", "is", "it", "its", "itself", "just", "let", "may",
+	"me", "might", "more", "most", "much", "must", "my", "myself", "neither",
+	"no", "nor", "not", "now", "of", "off", "on", "once", "only", "or",
+	"other", "ought", "our", "ours", "ourselves", "out", "over", "own", "per",
+	"same", "shall", "she", "should", "so", "some", "such", "than", "that",
+	"the", "their", "theirs", "them", "themselves", "then", "there", "these",
+	"they", "this", "those", "through", "thus", "to", "too", "under", "until",
+	"up", "upon", "us", "very", "via", "was", "we", "were", "what", "when",
+	"where", "whether", "which", "while", "who", "whom", "whose", "why",
+	"will", "with", "within", "without", "would", "yet", "you", "your",
+	"yours", "yourself", "yourselves",
+}
+
+// stopwordSet builds a lowercase lookup table from `words`.
+func stopwordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[strings.ToLower(word)] = true
+	}
+	return set
+}
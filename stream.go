@@ -0,0 +1,270 @@
+package prose
+
+import (
+	"context"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// streamLookahead is the amount of text that a SentenceStream tries to
+	// buffer before segmenting it.
+	streamLookahead = 64 << 10
+	// streamMaxBuffer bounds the size of a SentenceStream's buffer: a
+	// "sentence" that doesn't end within this many bytes is emitted as-is.
+	streamMaxBuffer = 1 << 20
+	// streamReadSize is the size of each read from the underlying io.Reader.
+	streamReadSize = 32 << 10
+	// streamMaxEmptyReads is the number of consecutive reads that may return
+	// no data (and no error) before we give up, as in bufio.
+	streamMaxEmptyReads = 100
+)
+
+// A SentenceStream reads text from an io.Reader and processes it one
+// sentence at a time.
+//
+// Unlike a Document, a SentenceStream never holds more than a bounded amount
+// of text in memory, which makes it suitable for arbitrarily large inputs.
+// Each Sentence it produces carries its own tokens and entities, with offsets
+// relative to the start of the stream.
+//
+// Its usage mirrors that of bufio.Scanner:
+//
+//	stream, err := prose.NewSentenceStream(file)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for stream.Next() {
+//	    sent := stream.Sentence()
+//	    // ...
+//	}
+//	if err := stream.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+type SentenceStream struct {
+	reader    io.Reader
+	segmenter *punktSentenceTokenizer
+	pipeline  []Component
+	model     *Model
+	ctx       context.Context
+
+	buf        []byte // text that has been read but not yet segmented
+	offset     int    // the byte offset of buf[0] within the stream
+	runeOffset int    // the rune offset of buf[0] within the stream
+	want       int    // the amount of text to buffer before segmenting
+	lookahead  int
+	maxBuffer  int
+	eof        bool
+
+	pending []Sentence
+	current Sentence
+	err     error
+}
+
+// NewSentenceStream creates a SentenceStream that reads from `r`.
+//
+// It accepts the same options as NewDocument, except that segmentation is
+// always performed (by the built-in segmenter) and any segmentation
+// Component is ignored.
+func NewSentenceStream(r io.Reader, opts ...DocOpt) (*SentenceStream, error) {
+	return NewSentenceStreamContext(context.Background(), r, opts...)
+}
+
+// NewSentenceStreamContext is NewSentenceStream, but processing stops once
+// `ctx` is done.
+func NewSentenceStreamContext(ctx context.Context, r io.Reader, opts ...DocOpt) (*SentenceStream, error) {
+	doc := Document{}
+	base := defaultOpts
+	for _, applyOpt := range opts {
+		applyOpt(&doc, &base)
+	}
+
+	pipeline, err := base.pipeline()
+	if err != nil {
+		return nil, &StageError{Stage: "pipeline", Err: err}
+	}
+
+	components := []Component{}
+	for _, component := range pipeline {
+		if component.Name() != SegmenterName {
+			components = append(components, component)
+		}
+	}
+
+	if doc.Model == nil {
+		doc.Model, err = defaultModel(requirements(components))
+		if err != nil {
+			return nil, &StageError{Stage: "model", Err: err}
+		}
+	}
+
+	segmenter, err := builtinSegmenter()
+	if err != nil {
+		return nil, &StageError{Stage: SegmenterName, Err: err}
+	}
+
+	return &SentenceStream{
+		reader:    r,
+		segmenter: segmenter,
+		pipeline:  components,
+		model:     doc.Model,
+		ctx:       ctx,
+		want:      streamLookahead,
+		lookahead: streamLookahead,
+		maxBuffer: streamMaxBuffer,
+	}, nil
+}
+
+// Next advances the stream to the next sentence, which will then be available
+// through Sentence. It returns false once the input has been exhausted or an
+// error has occurred.
+func (s *SentenceStream) Next() bool {
+	for len(s.pending) == 0 {
+		if s.err != nil || (s.eof && len(s.buf) == 0) {
+			return false
+		}
+		if s.err = s.ctx.Err(); s.err != nil {
+			return false
+		}
+		if s.err = s.fill(); s.err != nil {
+			return false
+		}
+		s.pending = s.split()
+	}
+
+	s.current, s.err = s.process(s.pending[0])
+	s.pending = s.pending[1:]
+
+	return s.err == nil
+}
+
+// Sentence returns the most recent sentence produced by Next.
+func (s *SentenceStream) Sentence() Sentence {
+	return s.current
+}
+
+// Err returns the first error encountered by the stream, if any.
+func (s *SentenceStream) Err() error {
+	return s.err
+}
+
+// fill reads from the underlying io.Reader until we've buffered enough text.
+func (s *SentenceStream) fill() error {
+	chunk := make([]byte, streamReadSize)
+	empty := 0
+	for !s.eof && len(s.buf) < s.want {
+		n, err := s.reader.Read(chunk)
+		s.buf = append(s.buf, chunk[:n]...)
+		if err == io.EOF {
+			s.eof = true
+		} else if err != nil {
+			return err
+		} else if n > 0 {
+			empty = 0
+		} else if empty++; empty >= streamMaxEmptyReads {
+			return io.ErrNoProgress
+		}
+	}
+	return nil
+}
+
+// split segments the buffered text, returning all of the sentences that we
+// can be sure are complete and discarding their text from the buffer.
+func (s *SentenceStream) split() []Sentence {
+	forced := !s.eof && len(s.buf) >= s.maxBuffer
+	final := s.eof || forced
+
+	text := s.buf
+	if !s.eof {
+		// We only segment up to the last complete token, since a partial one
+		// (e.g., "U." from "U.S.") could mislead the segmenter. If the buffer
+		// is full, we settle for the last complete rune.
+		cut := lastSpace(text)
+		if forced && cut == 0 {
+			cut = lastRuneBoundary(text)
+		}
+		text = text[:cut]
+	}
+
+	sents := s.segmenter.segment(string(text))
+	consumed := len(text)
+	if !final {
+		if len(sents) > 1 {
+			// The last sentence may continue beyond the buffer.
+			sents = sents[:len(sents)-1]
+			consumed = sents[len(sents)-1].End
+		} else if len(sents) == 1 || consumed == 0 {
+			// We haven't found the end of the first sentence yet, so we need
+			// to read more text.
+			s.want = min(2*s.want, s.maxBuffer)
+			return nil
+		}
+	}
+
+	for i := range sents {
+		sents[i].shift(s.offset, s.runeOffset)
+	}
+
+	s.offset += consumed
+	s.runeOffset += utf8.RuneCount(s.buf[:consumed])
+	s.buf = s.buf[:copy(s.buf, s.buf[consumed:])]
+	s.want = s.lookahead
+
+	return sents
+}
+
+// process runs the stream's pipeline over a single sentence.
+func (s *SentenceStream) process(sent Sentence) (Sentence, error) {
+	doc := Document{Text: sent.Text, Model: s.model, ctx: s.ctx}
+	for _, component := range s.pipeline {
+		if err := s.ctx.Err(); err != nil {
+			return sent, err
+		}
+		if err := component.Process(&doc); err != nil {
+			if ctxErr := s.ctx.Err(); ctxErr != nil {
+				return sent, ctxErr
+			}
+			return sent, &StageError{Stage: component.Name(), Err: err}
+		}
+	}
+
+	for _, tok := range doc.tokens {
+		tok.shift(sent.Start, sent.RuneStart)
+	}
+	for i := range doc.entities {
+		doc.entities[i].shift(sent.Start, sent.RuneStart)
+	}
+
+	sent.tokens = doc.tokens
+	sent.entities = doc.entities
+	sent.classification = doc.classification
+	sent.sentiment = doc.sentiment
+	return sent, nil
+}
+
+// lastSpace returns the index of the last whitespace character in `text`, or
+// 0 if there isn't one.
+func lastSpace(text []byte) int {
+	for i := len(text); i > 0; {
+		r, size := utf8.DecodeLastRune(text[:i])
+		i -= size
+		if unicode.IsSpace(r) {
+			return i
+		}
+	}
+	return 0
+}
+
+// lastRuneBoundary returns the length of the longest prefix of `text` that
+// doesn't end with an incomplete UTF-8 sequence.
+func lastRuneBoundary(text []byte) int {
+	start := len(text) - 1
+	for start > 0 && len(text)-start < utf8.UTFMax && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	if start < 0 || utf8.FullRune(text[start:]) {
+		return len(text)
+	}
+	return start
+}
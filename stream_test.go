@@ -0,0 +1,110 @@
+package prose
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSentenceStream(t *testing.T) {
+	text := string(readDataFile(filepath.Join(testdata, "sherlock.txt")))
+	opts := []DocOpt{WithTagging(false), WithExtraction(false)}
+
+	doc, err := NewDocument(text, opts...)
+	if err != nil {
+		panic(err)
+	}
+	expected := doc.Sentences()
+
+	stream, err := NewSentenceStream(strings.NewReader(text), opts...)
+	if err != nil {
+		panic(err)
+	}
+	// Use a small buffer to exercise sentences that span reads.
+	stream.lookahead, stream.want = 512, 512
+
+	observed := []Sentence{}
+	for stream.Next() {
+		observed = append(observed, stream.Sentence())
+	}
+	if stream.Err() != nil {
+		t.Fatalf("SentenceStream() unexpected error: %v", stream.Err())
+	}
+
+	if len(observed) != len(expected) {
+		t.Fatalf("SentenceStream() expected = %v sentences, got = %v",
+			len(expected), len(observed))
+	}
+	for i, sent := range observed {
+		if sent.Span != expected[i].Span || sent.Text != expected[i].Text {
+			t.Fatalf("SentenceStream() expected = %v, got = %v", expected[i], sent)
+		}
+		if !reflect.DeepEqual(sent.Tokens(), expected[i].Tokens()) {
+			t.Fatalf("SentenceStream() expected = %v, got = %v",
+				expected[i].Tokens(), sent.Tokens())
+		}
+	}
+}
+
+func TestSentenceStreamMaxBuffer(t *testing.T) {
+	// A long run of multi-byte runes without whitespace or punctuation.
+	text := "Hello there. " + strings.Repeat("é", 3000) + " The end."
+
+	stream, err := NewSentenceStream(strings.NewReader(text),
+		WithTagging(false), WithExtraction(false))
+	checkError(err)
+	stream.lookahead, stream.want, stream.maxBuffer = 512, 512, 1001
+
+	rebuilt := ""
+	for stream.Next() {
+		sent := stream.Sentence()
+		if !utf8.ValidString(sent.Text) {
+			t.Fatalf("SentenceStream() produced invalid UTF-8: %q", sent.Text)
+		}
+		if text[sent.Start:sent.End] != sent.Text {
+			t.Fatalf("SentenceStream() expected = %q, got = %q", text[sent.Start:sent.End], sent.Text)
+		}
+		rebuilt += sent.Text
+	}
+	if stream.Err() != nil {
+		t.Fatalf("SentenceStream() unexpected error: %v", stream.Err())
+	}
+
+	if expected := strings.Join(strings.Fields(text), ""); strings.Join(strings.Fields(rebuilt), "") != expected {
+		t.Errorf("SentenceStream() lost text: got = %d bytes, expected = %d", len(rebuilt), len(expected))
+	}
+}
+
+type emptyReader struct{}
+
+func (emptyReader) Read(p []byte) (int, error) { return 0, nil }
+
+func TestSentenceStreamNoProgress(t *testing.T) {
+	stream, err := NewSentenceStream(emptyReader{}, WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	if stream.Next() {
+		t.Fatalf("SentenceStream() expected no sentences, got = %v", stream.Sentence())
+	}
+	if stream.Err() != io.ErrNoProgress {
+		t.Errorf("SentenceStream() expected = %v, got = %v", io.ErrNoProgress, stream.Err())
+	}
+}
+
+func BenchmarkSentenceStream(b *testing.B) {
+	text := string(readDataFile(filepath.Join(testdata, "sherlock.txt")))
+	for n := 0; n < b.N; n++ {
+		stream, err := NewSentenceStream(strings.NewReader(text))
+		if err != nil {
+			panic(err)
+		}
+		for stream.Next() {
+		}
+		if stream.Err() != nil {
+			panic(stream.Err())
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package prose
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// A RankedSentence is a Sentence chosen for a summary (see
+// Document.Summarize).
+type RankedSentence struct {
+	Sentence
+
+	Index int     // The sentence's position within the Document.
+	Score float64 // The sentence's TextRank score; higher is more central.
+}
+
+// SummaryOpts controls summarization:
+type SummaryOpts struct {
+	// PositionBias, between 0 (the default) and 1, controls how strongly
+	// earlier sentences are preferred.
+	PositionBias float64
+}
+
+// A SummaryOpt represents a setting that changes summarization.
+type SummaryOpt func(opts *SummaryOpts)
+
+// WithPositionBias favors earlier sentences, which often carry a text's main
+// point (e.g., the opening post of a thread).
+//
+// A `weight` of 0 disables the bias, while 1 draws all of PageRank's random
+// jumps from a distribution that decays with position (1/(i+1)).
+func WithPositionBias(weight float64) SummaryOpt {
+	return func(opts *SummaryOpts) {
+		opts.PositionBias = math.Max(0, math.Min(1, weight))
+	}
+}
+
+// Summarize returns the `n` most central of `doc`'s sentences, in their
+// original order.
+//
+// Sentences are ranked with TextRank (Mihalcea and Tarau, 2004): PageRank
+// over a graph whose edges are weighted by the word overlap between each
+// pair of sentences (ignoring stopwords). Words are compared by their lemmas
+// when lemmatization is enabled. Summarization requires that segmentation be
+// enabled.
+func (doc *Document) Summarize(n int, opts ...SummaryOpt) []RankedSentence {
+	base := SummaryOpts{}
+	for _, applyOpt := range opts {
+		applyOpt(&base)
+	}
+
+	if n <= 0 {
+		return nil
+	}
+
+	ranked := rankSentences(doc.sentences, base)
+	if n < len(ranked) {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].Score > ranked[j].Score
+		})
+		ranked = ranked[:n]
+		sort.Slice(ranked, func(i, j int) bool {
+			return ranked[i].Index < ranked[j].Index
+		})
+	}
+
+	return ranked
+}
+
+// rankSentences scores each of `sents` with TextRank.
+func rankSentences(sents []Sentence, opts SummaryOpts) []RankedSentence {
+	if len(sents) == 0 {
+		return nil
+	}
+
+	stopwords := stopwordSet(englishStopwords)
+	words := make([]map[string]bool, len(sents))
+	for i, sent := range sents {
+		words[i] = sentenceWords(sent.tokens, stopwords)
+	}
+
+	edges := make([]map[int]float64, len(sents))
+	for i := range sents {
+		edges[i] = make(map[int]float64)
+	}
+	for i := range sents {
+		for j := i + 1; j < len(sents); j++ {
+			if sim := sentenceSimilarity(words[i], words[j]); sim > 0 {
+				edges[i][j] = sim
+				edges[j][i] = sim
+			}
+		}
+	}
+
+	var teleport []float64
+	if opts.PositionBias > 0 {
+		teleport = positionPrior(len(sents), opts.PositionBias)
+	}
+
+	ranked := make([]RankedSentence, len(sents))
+	for i, score := range pageRank(edges, teleport) {
+		ranked[i] = RankedSentence{Sentence: sents[i], Index: i, Score: score}
+	}
+	return ranked
+}
+
+// sentenceWords returns the distinct content words of a sentence.
+func sentenceWords(tokens []*Token, stopwords map[string]bool) map[string]bool {
+	words := make(map[string]bool)
+	for _, tok := range tokens {
+		word := strings.ToLower(tok.Text)
+		if stopwords[word] || !hasLetter(word) {
+			continue
+		}
+		if tok.Lemma != "" {
+			word = strings.ToLower(tok.Lemma)
+		}
+		words[word] = true
+	}
+	return words
+}
+
+// sentenceSimilarity is TextRank's measure of the content overlap between two
+// sentences:
+//
+//	|{w : w in a and w in b}| / (log |a| + log |b|)
+func sentenceSimilarity(a, b map[string]bool) float64 {
+	norm := math.Log(float64(len(a))) + math.Log(float64(len(b)))
+	if norm <= 0 {
+		// Both sentences have (at most) one word.
+		norm = 1
+	}
+
+	overlap := 0.0
+	for word := range a {
+		if b[word] {
+			overlap++
+		}
+	}
+	return overlap / norm
+}
+
+// positionPrior mixes a uniform distribution over `n` sentences with one that
+// decays with position (1/(i+1)), giving the latter weight `bias`.
+func positionPrior(n int, bias float64) []float64 {
+	total := 0.0
+	for i := 0; i < n; i++ {
+		total += 1 / float64(i+1)
+	}
+
+	prior := make([]float64, n)
+	for i := range prior {
+		prior[i] = (1-bias)/float64(n) + bias/(float64(i+1)*total)
+	}
+	return prior
+}
@@ -0,0 +1,56 @@
+package prose
+
+import (
+	"testing"
+)
+
+const supportThread = "Hi, thanks for getting back to me. " +
+	"The export to PDF fails whenever the report contains images. " +
+	"I tried exporting the same report without images and the PDF export worked. " +
+	"Our team has been waiting on this for a week. " +
+	"Could you check whether the PDF export handles large images in reports? " +
+	"Have a nice weekend."
+
+func TestSummarize(t *testing.T) {
+	doc, err := NewDocument(supportThread, WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	summary := doc.Summarize(2)
+	if len(summary) != 2 {
+		t.Fatalf("Summarize() expected 2 sentences, got = %d", len(summary))
+	}
+
+	if summary[0].Index >= summary[1].Index {
+		t.Errorf("Summarize() expected the original order, got = %v, %v",
+			summary[0].Index, summary[1].Index)
+	}
+
+	for _, sent := range summary {
+		if doc.Text[sent.Start:sent.End] != sent.Text {
+			t.Errorf("Summarize() bad offsets for %q: %v", sent.Text, sent.Span)
+		}
+		if sent.Index == 0 || sent.Index == 5 {
+			t.Errorf("Summarize() unexpected sentence: %q (%v)", sent.Text, sent.Score)
+		}
+	}
+
+	all := doc.Summarize(10)
+	if len(all) != 6 || all[0].Score >= all[1].Score {
+		t.Errorf("Summarize() unexpected ranking: %v", all)
+	}
+	if doc.Summarize(0) != nil {
+		t.Errorf("Summarize(0) expected no sentences")
+	}
+}
+
+func TestSummarizePositionBias(t *testing.T) {
+	doc, err := NewDocument(supportThread, WithTagging(false), WithExtraction(false))
+	checkError(err)
+
+	plain := doc.Summarize(6)
+	biased := doc.Summarize(6, WithPositionBias(1))
+	if biased[0].Score <= plain[0].Score || biased[5].Score >= plain[5].Score {
+		t.Errorf("WithPositionBias() expected to favor earlier sentences: %v, %v",
+			plain, biased)
+	}
+}
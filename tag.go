@@ -21,6 +21,7 @@
 package prose
 
 import (
+	"context"
 	"math"
 	"regexp"
 	"strconv"
@@ -67,9 +68,7 @@ type averagedPerceptron struct {
 	tagMap  map[string]string
 	weights map[string]map[string]float64
 
-	// TODO: Training
-	//
-	// instances float64
+	instances float64
 }
 
 // newAveragedPerceptron creates a new AveragedPerceptron model.
@@ -135,22 +134,6 @@ func (pt *perceptronTagger) train(sentences TupleSlice, iterations int) {
 	pt.model.averageWeights()
 }
 
-func (m *averagedPerceptron) averageWeights() {
-	for feat, weights := range m.weights {
-		newWeights := make(map[string]float64)
-		for class, weight := range weights {
-			key := feat + "-" + class
-			total := m.totals[key]
-			total += (m.instances - m.stamps[key]) * weight
-			averaged, _ := stats.Round(total/m.instances, 3)
-			if averaged != 0.0 {
-				newWeights[class] = averaged
-			}
-		}
-		m.weights[feat] = newWeights
-	}
-}
-
 // newTrainedPerceptronTagger creates a new PerceptronTagger using the given
 // model.
 func newTrainedPerceptronTagger(model *averagedPerceptron) *perceptronTagger {
@@ -197,6 +180,24 @@ func maxValue(m map[string]int) (string, int) {
 		}
 	}
 	return key, maxValue
+}*/
+
+// averageWeights replaces each weight with its average over all of the
+// updates made during training.
+func (m *averagedPerceptron) averageWeights() {
+	for feat, weights := range m.weights {
+		newWeights := make(map[string]float64)
+		for class, weight := range weights {
+			key := feat + "-" + class
+			total := m.totals[key]
+			total += (m.instances - m.stamps[key]) * weight
+			averaged := math.Round(1000*total/m.instances) / 1000
+			if averaged != 0.0 {
+				newWeights[class] = averaged
+			}
+		}
+		m.weights[feat] = newWeights
+	}
 }
 
 func get(k string, m map[string]float64) float64 {
@@ -206,6 +207,8 @@ func get(k string, m map[string]float64) float64 {
 	return 0.0
 }
 
+// update adjusts the model's weights after it predicted `guess` instead of
+// `truth`.
 func (m *averagedPerceptron) update(truth, guess string, feats map[string]float64) {
 	m.instances++
 	if truth == guess {
@@ -225,7 +228,7 @@ func (m *averagedPerceptron) update(truth, guess string, feats map[string]float6
 
 func (m *averagedPerceptron) updateFeat(c, f string, v, w float64) {
 	key := f + "-" + c
-	m.totals[key] = (m.instances - m.stamps[key]) * w
+	m.totals[key] += (m.instances - m.stamps[key]) * v
 	m.stamps[key] = m.instances
 	m.weights[f][c] = w + v
 }
@@ -234,7 +237,7 @@ func (m *averagedPerceptron) addClass(class string) {
 	if !stringInSlice(class, m.classes) {
 		m.classes = append(m.classes, class)
 	}
-}*/
+}
 
 // perceptronTagger is a port of Textblob's "fast and accurate" POS tagger.
 // See https://github.com/sloria/textblob-aptagger for details.
@@ -244,25 +247,33 @@ type perceptronTagger struct {
 
 // newPerceptronTagger creates a new PerceptronTagger and loads the built-in
 // AveragedPerceptron model.
-func newPerceptronTagger() *perceptronTagger {
+func newPerceptronTagger() (*perceptronTagger, error) {
 	var wts map[string]map[string]float64
 	var tags map[string]string
 	var classes []string
 
-	dec := getAsset("AveragedPerceptron", "classes.gob")
-	checkError(dec.Decode(&classes))
-
-	dec = getAsset("AveragedPerceptron", "tags.gob")
-	checkError(dec.Decode(&tags))
-
-	dec = getAsset("AveragedPerceptron", "weights.gob")
-	checkError(dec.Decode(&wts))
+	if err := loadAsset("AveragedPerceptron", "classes.gob", &classes); err != nil {
+		return nil, err
+	}
+	if err := loadAsset("AveragedPerceptron", "tags.gob", &tags); err != nil {
+		return nil, err
+	}
+	if err := loadAsset("AveragedPerceptron", "weights.gob", &wts); err != nil {
+		return nil, err
+	}
 
-	return &perceptronTagger{model: newAveragedPerceptron(wts, tags, classes)}
+	return &perceptronTagger{model: newAveragedPerceptron(wts, tags, classes)}, nil
 }
 
 // tag takes a slice of words and returns a slice of tagged tokens.
 func (pt *perceptronTagger) tag(tokens []*Token) []*Token {
+	tagged, _ := pt.tagContext(context.Background(), tokens)
+	return tagged
+}
+
+// tagContext is tag, but it stops early (returning only the tokens that have
+// been tagged) if `ctx` is done.
+func (pt *perceptronTagger) tagContext(ctx context.Context, tokens []*Token) ([]*Token, error) {
 	var tag string
 	var found bool
 
@@ -277,6 +288,11 @@ func (pt *perceptronTagger) tag(tokens []*Token) []*Token {
 	context[length-2] = "-END-"
 	context[length-1] = "-END2-"
 	for i := 0; i < len(tokens); i++ {
+		if i%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return tokens[:i], err
+			}
+		}
 		word := tokens[i].Text
 		if word == "-" {
 			tag = "-"
@@ -297,10 +313,15 @@ func (pt *perceptronTagger) tag(tokens []*Token) []*Token {
 		p1 = tag
 	}
 
-	return tokens
+	return tokens, nil
 }
 
 func (m *averagedPerceptron) predict(features map[string]float64) string {
+	return max(m.scores(features))
+}
+
+// scores computes the score of each class given `features`.
+func (m *averagedPerceptron) scores(features map[string]float64) map[string]float64 {
 	var weights map[string]float64
 	var found bool
 
@@ -313,7 +334,7 @@ func (m *averagedPerceptron) predict(features map[string]float64) string {
 			scores[label] += value * weight
 		}
 	}
-	return max(scores)
+	return scores
 }
 
 func max(scores map[string]float64) string {
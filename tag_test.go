@@ -38,7 +38,8 @@ func TestTagSimple(t *testing.T) {
 }
 
 func TestTagTreebank(t *testing.T) {
-	tagger := newPerceptronTagger()
+	tagger, err := newPerceptronTagger()
+	checkError(err)
 	tokens, expected := []*Token{}, []string{}
 
 	tags := readDataFile(filepath.Join(testdata, "treebank_tags.json"))
@@ -61,7 +62,8 @@ func TestTagTreebank(t *testing.T) {
 }
 
 func BenchmarkTag(b *testing.B) {
-	tagger := newPerceptronTagger()
+	tagger, err := newPerceptronTagger()
+	checkError(err)
 	tokens := []*Token{}
 
 	treebank := readDataFile(filepath.Join(testdata, "treebank_tokens.json"))
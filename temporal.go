@@ -0,0 +1,592 @@
+package prose
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TemporalComponent returns a pipeline stage that labels temporal expressions
+// as DATE or TIME entities, setting each one's Value to its ISO-8601
+// normalization relative to `reference`.
+//
+// The recognizer is rule-based (and therefore deterministic). For a reference
+// time of Wednesday, June 12, 2024 at 9:41, it understands expressions such as
+//
+//	"12/05/2023", "2023-12-05", "Dec. 5th, 2023"  DATE  2023-12-05
+//	"December 2023", "in December"               DATE  2023-12, 2024-12
+//	"in 2023"                                    DATE  2023
+//	"Q3 2024", "the third quarter of 2024"       DATE  2024-07-01/2024-09-30
+//	"today", "the day after tomorrow"            DATE  2024-06-12, 2024-06-14
+//	"next Tuesday", "last Tuesday", "Tuesday"    DATE  2024-06-18, 2024-06-11, 2024-06-11
+//	"next week", "last month", "this year"       DATE  2024-W25, 2024-05, 2024
+//	"3 weeks ago", "in two days"                 DATE  2024-05-22, 2024-06-14
+//	"3pm", "tomorrow at 15:45"                   TIME  2024-06-12T15:00, 2024-06-13T15:45
+//	"in 20 minutes"                              TIME  2024-06-12T10:01
+//
+// A bare weekday (or one preceded by "this") refers to that day of the
+// reference's (Monday-based) week, while "next" and "last" refer to its
+// closest occurrence after or before the reference. Dates with slashes are
+// read as month/day/year, and dates without a year fall in the reference's
+// year. Bare month names and years are only recognized after a preposition
+// (e.g., "in", "since" or "by").
+//
+// Matches take precedence over any overlapping entities found by earlier
+// stages. A TemporalComponent should run after the extracter (see
+// WithTemporalEntities).
+func TemporalComponent(reference time.Time) Component {
+	return temporalComponent{reference: reference}
+}
+
+// WithTemporalEntities adds a TemporalComponent that's relative to
+// `reference` to the pipeline, right after the extracter (or at the end, if
+// there's no extracter).
+func WithTemporalEntities(reference time.Time) DocOpt {
+	return editPipeline(func(pipeline []Component) ([]Component, error) {
+		component := TemporalComponent(reference)
+		if idx, err := findComponent(pipeline, ExtracterName); err == nil {
+			return insertComponent(pipeline, idx+1, component), nil
+		}
+		return append(pipeline, component), nil
+	})
+}
+
+type temporalComponent struct {
+	reference time.Time
+}
+
+func (c temporalComponent) Name() string { return TemporalName }
+
+func (c temporalComponent) Process(doc *Document) error {
+	var matched []Entity
+	for _, group := range doc.tokenGroups() {
+		matched = append(matched, findTemporal(doc.Text, group, c.reference)...)
+	}
+
+	entities := mergeEntities(matched, doc.entities, RulerFirst)
+	relabel(doc.tokens, entities)
+	doc.SetEntities(entities)
+
+	return nil
+}
+
+// temporalGrain is the precision of a temporalValue.
+type temporalGrain int
+
+const (
+	minuteGrain temporalGrain = iota
+	dayGrain
+	weekGrain
+	monthGrain
+	quarterGrain
+	yearGrain
+)
+
+// A temporalValue is the start of a period of time of the given length.
+type temporalValue struct {
+	t     time.Time
+	grain temporalGrain
+}
+
+// String formats `v` in ISO-8601, using an interval for quarters (which have
+// no notation of their own).
+func (v temporalValue) String() string {
+	switch v.grain {
+	case minuteGrain:
+		return v.t.Format("2006-01-02T15:04")
+	case dayGrain:
+		return v.t.Format("2006-01-02")
+	case weekGrain:
+		year, week := v.t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case monthGrain:
+		return v.t.Format("2006-01")
+	case quarterGrain:
+		return v.t.Format("2006-01-02") + "/" + v.t.AddDate(0, 3, -1).Format("2006-01-02")
+	}
+	return v.t.Format("2006")
+}
+
+var (
+	temporalMonths = map[string]time.Month{
+		"january": time.January, "jan": time.January,
+		"february": time.February, "feb": time.February,
+		"march": time.March, "mar": time.March,
+		"april": time.April, "apr": time.April,
+		"may":  time.May,
+		"june": time.June, "jun": time.June,
+		"july": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September, "sept": time.September,
+		"october": time.October, "oct": time.October,
+		"november": time.November, "nov": time.November,
+		"december": time.December, "dec": time.December,
+	}
+	temporalWeekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+		"wednesday": time.Wednesday, "thursday": time.Thursday,
+		"friday": time.Friday, "saturday": time.Saturday,
+	}
+	temporalNumbers = map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11,
+		"twelve": 12, "couple": 2,
+	}
+	temporalOrdinals = map[string]int{
+		"first": 1, "second": 2, "third": 3, "fourth": 4,
+	}
+	temporalShifts = map[string]int{
+		"last": -1, "previous": -1, "this": 0, "next": 1,
+	}
+	temporalPrepositions = map[string]bool{
+		"in": true, "during": true, "since": true, "until": true, "till": true,
+		"by": true, "before": true, "after": true, "from": true,
+		"through": true, "throughout": true,
+	}
+
+	isoDateRe   = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	slashDateRe = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})/(\d{4}|\d{2})$`)
+	clockRe     = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|a\.m\.?|p\.m\.?)?$`)
+)
+
+// A temporalParser matches temporal expressions within a single sentence.
+type temporalParser struct {
+	tokens []*Token
+	words  []string // The tokens' lowercased text.
+	ref    time.Time
+	today  time.Time
+}
+
+// findTemporal finds the temporal expressions within a single sentence.
+func findTemporal(text string, tokens []*Token, ref time.Time) []Entity {
+	p := temporalParser{
+		tokens: tokens,
+		words:  make([]string, len(tokens)),
+		ref:    ref,
+		today:  time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, ref.Location()),
+	}
+	for i, tok := range tokens {
+		p.words[i] = strings.ToLower(tok.Text)
+	}
+
+	var entities []Entity
+	for i := 0; i < len(tokens); {
+		v, end, ok := p.parse(i)
+		if !ok {
+			i++
+			continue
+		}
+
+		label := "DATE"
+		if v.grain == minuteGrain {
+			label = "TIME"
+		}
+		entities = append(entities, makeEntity(text, tokens[i:end], label, v.String()))
+		i = end
+	}
+
+	return entities
+}
+
+// word returns the lowercased text of the i-th token, or "" if there's none.
+func (p *temporalParser) word(i int) string {
+	if i < 0 || i >= len(p.words) {
+		return ""
+	}
+	return p.words[i]
+}
+
+// parse matches the longest temporal expression starting at the i-th token.
+func (p *temporalParser) parse(i int) (temporalValue, int, bool) {
+	if v, end, ok := p.date(i); ok {
+		// "tomorrow at 3pm"
+		if v.grain == dayGrain {
+			j := end
+			if p.word(j) == "at" {
+				j++
+			}
+			if minutes, clockEnd, ok := p.clock(j); ok {
+				return atClock(v, minutes), clockEnd, true
+			}
+		}
+		return v, end, true
+	}
+
+	if minutes, end, ok := p.clock(i); ok {
+		// "3pm on Tuesday"
+		day := temporalValue{t: p.today, grain: dayGrain}
+		j := end
+		if p.word(j) == "on" {
+			j++
+		}
+		if v, dateEnd, ok := p.date(j); ok && v.grain == dayGrain {
+			day, end = v, dateEnd
+		}
+		return atClock(day, minutes), end, true
+	}
+
+	return temporalValue{}, i, false
+}
+
+// date matches a DATE, or a TIME relative to the reference (e.g., "in 20
+// minutes").
+func (p *temporalParser) date(i int) (temporalValue, int, bool) {
+	w := p.word(i)
+
+	switch w {
+	case "today":
+		return p.day(0), i + 1, true
+	case "tomorrow":
+		return p.day(1), i + 1, true
+	case "yesterday":
+		return p.day(-1), i + 1, true
+	case "the":
+		if p.word(i+1) == "day" {
+			switch p.word(i+2) + " " + p.word(i+3) {
+			case "after tomorrow":
+				return p.day(2), i + 4, true
+			case "before yesterday":
+				return p.day(-2), i + 4, true
+			}
+		}
+	}
+
+	if shift, ok := temporalShifts[w]; ok {
+		next := p.word(i + 1)
+		if wd, ok := temporalWeekdays[next]; ok {
+			return p.weekday(wd, shift), i + 2, true
+		}
+		switch next {
+		case "week":
+			return temporalValue{t: p.today.AddDate(0, 0, 7*shift), grain: weekGrain}, i + 2, true
+		case "month":
+			t := time.Date(p.today.Year(), p.today.Month()+time.Month(shift), 1, 0, 0, 0, 0, p.ref.Location())
+			return temporalValue{t: t, grain: monthGrain}, i + 2, true
+		case "year":
+			t := time.Date(p.today.Year()+shift, time.January, 1, 0, 0, 0, 0, p.ref.Location())
+			return temporalValue{t: t, grain: yearGrain}, i + 2, true
+		}
+	}
+
+	if wd, ok := temporalWeekdays[w]; ok {
+		return p.weekday(wd, 0), i + 1, true
+	}
+	if v, ok := p.numericDate(w); ok {
+		return v, i + 1, true
+	}
+
+	for _, match := range []func(int) (temporalValue, int, bool){
+		p.relative, p.quarter, p.calendarDate,
+	} {
+		if v, end, ok := match(i); ok {
+			return v, end, true
+		}
+	}
+
+	// Bare months and years are only dates in context ("in December").
+	if temporalPrepositions[p.word(i-1)] {
+		if m, end, ok := p.month(i); ok {
+			return p.monthOf(p.today.Year(), m), end, true
+		} else if year, ok := parseYear(w); ok {
+			t := time.Date(year, time.January, 1, 0, 0, 0, 0, p.ref.Location())
+			return temporalValue{t: t, grain: yearGrain}, i + 1, true
+		}
+	}
+
+	return temporalValue{}, i, false
+}
+
+// relative matches an offset from the reference, such as "3 weeks ago", "in
+// a couple of days" or "20 minutes from now".
+func (p *temporalParser) relative(i int) (temporalValue, int, bool) {
+	j, sign := i, 0
+	if p.word(j) == "in" {
+		j, sign = j+1, 1
+	}
+
+	if p.word(j) == "a" && p.word(j+1) == "couple" {
+		// "a couple of days"
+		j++
+	}
+
+	n, ok := temporalNumbers[p.word(j)]
+	if !ok {
+		if n, ok = parseCount(p.word(j)); !ok {
+			return temporalValue{}, i, false
+		}
+	}
+	j++
+	if p.word(j-1) == "couple" && p.word(j) == "of" {
+		j++
+	}
+	unit := strings.TrimSuffix(p.word(j), "s")
+	j++
+
+	if sign == 0 {
+		switch p.word(j) {
+		case "ago", "earlier":
+			j, sign = j+1, -1
+		case "later":
+			j, sign = j+1, 1
+		case "from":
+			if p.word(j+1) == "now" {
+				j, sign = j+2, 1
+			}
+		}
+	}
+	if sign == 0 {
+		return temporalValue{}, i, false
+	}
+	n *= sign
+
+	switch unit {
+	case "minute", "min":
+		return temporalValue{t: p.ref.Add(time.Duration(n) * time.Minute), grain: minuteGrain}, j, true
+	case "hour", "hr":
+		return temporalValue{t: p.ref.Add(time.Duration(n) * time.Hour), grain: minuteGrain}, j, true
+	case "day":
+		return p.day(n), j, true
+	case "week":
+		return p.day(7 * n), j, true
+	case "month":
+		return temporalValue{t: p.today.AddDate(0, n, 0), grain: dayGrain}, j, true
+	case "year":
+		return temporalValue{t: p.today.AddDate(n, 0, 0), grain: dayGrain}, j, true
+	}
+	return temporalValue{}, i, false
+}
+
+// quarter matches a quarter, such as "Q3 2024" or "the third quarter of
+// 2024". Without a year, it's the reference's.
+func (p *temporalParser) quarter(i int) (temporalValue, int, bool) {
+	j, q := i, 0
+	if p.word(j) == "the" {
+		j++
+	}
+
+	w := p.word(j)
+	if len(w) == 2 && w[0] == 'q' && w[1] >= '1' && w[1] <= '4' {
+		j, q = j+1, int(w[1]-'0')
+	} else if n, ok := temporalOrdinals[w]; ok && p.word(j+1) == "quarter" {
+		j, q = j+2, n
+	} else {
+		return temporalValue{}, i, false
+	}
+
+	year := p.today.Year()
+	if y, ok := parseYear(p.word(j)); ok {
+		year, j = y, j+1
+	} else if y, ok := parseYear(p.word(j + 1)); ok && p.word(j) == "of" {
+		year, j = y, j+2
+	}
+
+	t := time.Date(year, time.Month(3*q-2), 1, 0, 0, 0, 0, p.ref.Location())
+	return temporalValue{t: t, grain: quarterGrain}, j, true
+}
+
+// calendarDate matches a date that uses a month's name, such as "Dec. 5th,
+// 2023", "5 December 2023", "December 5" or "December 2023".
+func (p *temporalParser) calendarDate(i int) (temporalValue, int, bool) {
+	if m, j, ok := p.month(i); ok {
+		if d, ok := parseDay(p.word(j)); ok {
+			year, end := p.today.Year(), j+1
+			if y, ok := parseYear(p.word(end)); ok {
+				year, end = y, end+1
+			} else if y, ok := parseYear(p.word(end + 1)); ok && p.word(end) == "," {
+				year, end = y, end+2
+			}
+			if v, ok := p.dayOf(year, m, d); ok {
+				return v, end, true
+			}
+		} else if y, ok := parseYear(p.word(j)); ok {
+			return p.monthOf(y, m), j + 1, true
+		}
+	} else if d, ok := parseDay(p.word(i)); ok {
+		if m, end, ok := p.month(i + 1); ok {
+			year := p.today.Year()
+			if y, ok := parseYear(p.word(end)); ok {
+				year, end = y, end+1
+			}
+			if v, ok := p.dayOf(year, m, d); ok {
+				return v, end, true
+			}
+		}
+	}
+	return temporalValue{}, i, false
+}
+
+// numericDate matches a date in ISO-8601 ("2023-12-05") or U.S.
+// ("12/05/2023" or "12/05/23") format.
+func (p *temporalParser) numericDate(w string) (temporalValue, bool) {
+	var year, month, day int
+	if m := isoDateRe.FindStringSubmatch(w); m != nil {
+		year, month, day = atoi(m[1]), atoi(m[2]), atoi(m[3])
+	} else if m := slashDateRe.FindStringSubmatch(w); m != nil {
+		month, day, year = atoi(m[1]), atoi(m[2]), atoi(m[3])
+		if len(m[3]) == 2 {
+			year += 2000
+		}
+	} else {
+		return temporalValue{}, false
+	}
+	return p.dayOf(year, time.Month(month), day)
+}
+
+// clock matches a time of day ("3pm", "3:30 p.m.", "15:45" or "noon"),
+// returning it as the number of minutes since midnight.
+func (p *temporalParser) clock(i int) (int, int, bool) {
+	w := p.word(i)
+	switch w {
+	case "noon", "midday":
+		return 12 * 60, i + 1, true
+	case "midnight":
+		return 0, i + 1, true
+	}
+
+	m := clockRe.FindStringSubmatch(w)
+	if m == nil {
+		return 0, i, false
+	}
+
+	end, meridiem := i+1, m[3]
+	if meridiem == "" {
+		switch p.word(end) {
+		case "am", "pm", "a.m.", "p.m.", "a.m", "p.m":
+			end, meridiem = end+1, p.word(end)
+		}
+	}
+
+	hour, minute := atoi(m[1]), 0
+	if m[2] != "" {
+		minute = atoi(m[2])
+	} else if meridiem == "" {
+		// A bare number isn't a time.
+		return 0, i, false
+	}
+
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return 0, i, false
+		}
+		hour %= 12
+		if meridiem[0] == 'p' {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, i, false
+	}
+
+	return 60*hour + minute, end, true
+}
+
+// month matches a (capitalized) month name, such as "December" or "Dec.",
+// returning the index of the token that follows it.
+//
+// The tokenizer splits the period off of some abbreviations ("Sept", "."),
+// so we include it in the match.
+func (p *temporalParser) month(i int) (time.Month, int, bool) {
+	if i >= len(p.tokens) || p.tokens[i].Text == "" ||
+		!unicode.IsUpper([]rune(p.tokens[i].Text)[0]) {
+		return 0, i, false
+	}
+
+	name := strings.TrimSuffix(p.words[i], ".")
+	m, ok := temporalMonths[name]
+	if !ok {
+		return 0, i, false
+	}
+
+	end := i + 1
+	if name != strings.ToLower(m.String()) && name == p.words[i] && p.word(end) == "." &&
+		p.tokens[end].Start == p.tokens[i].End {
+		end++
+	}
+	return m, end, true
+}
+
+// day returns the date `n` days from the reference.
+func (p *temporalParser) day(n int) temporalValue {
+	return temporalValue{t: p.today.AddDate(0, 0, n), grain: dayGrain}
+}
+
+// weekday returns the given day of the reference's week (shift = 0), or its
+// next (shift = 1) or last (shift = -1) occurrence.
+func (p *temporalParser) weekday(wd time.Weekday, shift int) temporalValue {
+	today := int(p.today.Weekday())
+	switch {
+	case shift > 0:
+		n := (int(wd) - today + 7) % 7
+		if n == 0 {
+			n = 7
+		}
+		return p.day(n)
+	case shift < 0:
+		n := (today - int(wd) + 7) % 7
+		if n == 0 {
+			n = 7
+		}
+		return p.day(-n)
+	}
+	monday := -((today + 6) % 7)
+	return p.day(monday + (int(wd)+6)%7)
+}
+
+// dayOf returns the given date, if it's valid.
+func (p *temporalParser) dayOf(year int, month time.Month, day int) (temporalValue, bool) {
+	t := time.Date(year, month, day, 0, 0, 0, 0, p.ref.Location())
+	if t.Year() != year || t.Month() != month || t.Day() != day {
+		return temporalValue{}, false
+	}
+	return temporalValue{t: t, grain: dayGrain}, true
+}
+
+func (p *temporalParser) monthOf(year int, month time.Month) temporalValue {
+	t := time.Date(year, month, 1, 0, 0, 0, 0, p.ref.Location())
+	return temporalValue{t: t, grain: monthGrain}
+}
+
+// atClock returns the given time of day on the date `day`.
+func atClock(day temporalValue, minutes int) temporalValue {
+	t := day.t
+	t = time.Date(t.Year(), t.Month(), t.Day(), minutes/60, minutes%60, 0, 0, t.Location())
+	return temporalValue{t: t, grain: minuteGrain}
+}
+
+// parseYear matches a four-digit year between 1000 and 2999.
+func parseYear(w string) (int, bool) {
+	if len(w) != 4 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(w)
+	return year, err == nil && year >= 1000 && year < 3000
+}
+
+// parseDay matches a day of the month, such as "5" or "5th".
+func parseDay(w string) (int, bool) {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		w = strings.TrimSuffix(w, suffix)
+	}
+	if len(w) > 2 {
+		return 0, false
+	}
+	day, err := strconv.Atoi(w)
+	return day, err == nil && day >= 1 && day <= 31
+}
+
+// parseCount matches a small, positive number written with digits.
+func parseCount(w string) (int, bool) {
+	if len(w) > 4 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(w)
+	return n, err == nil && n > 0
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
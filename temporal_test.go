@@ -0,0 +1,122 @@
+package prose
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Wednesday, June 12, 2024 at 9:41.
+var temporalReference = time.Date(2024, time.June, 12, 9, 41, 30, 0, time.UTC)
+
+func temporalStrings(text string) []string {
+	doc, err := NewDocument(text,
+		WithTagging(false),
+		WithExtraction(false),
+		UsingPipeline(
+			SegmenterComponent(),
+			TokenizerComponent(NewIterTokenizer()),
+			TemporalComponent(temporalReference)))
+	checkError(err)
+
+	observed := []string{}
+	for _, ent := range doc.Entities() {
+		observed = append(observed, ent.Text+"/"+ent.Label+"/"+ent.Value)
+	}
+	return observed
+}
+
+func TestTemporalDates(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"It shipped on 12/05/2023 and again on 2023-12-05.",
+			[]string{"12/05/2023/DATE/2023-12-05", "2023-12-05/DATE/2023-12-05"}},
+		{"It shipped on Dec. 5th, 2023 (or 5 December 2023, or 1/2/24).",
+			[]string{"Dec. 5th, 2023/DATE/2023-12-05", "5 December 2023/DATE/2023-12-05", "1/2/24/DATE/2024-01-02"}},
+		{"We met on June 3 and will meet again in December or in 2025.",
+			[]string{"June 3/DATE/2024-06-03", "December/DATE/2024-12", "2025/DATE/2025"}},
+		{"Revenue fell in Q3 2024 after a strong second quarter.",
+			[]string{"Q3 2024/DATE/2024-07-01/2024-09-30", "second quarter/DATE/2024-04-01/2024-06-30"}},
+		{"Compare the third quarter of 2023 with March 2024.",
+			[]string{"the third quarter of 2023/DATE/2023-07-01/2023-09-30", "March 2024/DATE/2024-03"}},
+		{"I arrived yesterday and leave the day after tomorrow.",
+			[]string{"yesterday/DATE/2024-06-11", "the day after tomorrow/DATE/2024-06-14"}},
+		{"Call me next Tuesday, not last Tuesday or Friday.",
+			[]string{"next Tuesday/DATE/2024-06-18", "last Tuesday/DATE/2024-06-11", "Friday/DATE/2024-06-14"}},
+		{"Sales rose last month and will rise next week and next year.",
+			[]string{"last month/DATE/2024-05", "next week/DATE/2024-W25", "next year/DATE/2025"}},
+		{"It broke 3 weeks ago and will be fixed in two days.",
+			[]string{"3 weeks ago/DATE/2024-05-22", "in two days/DATE/2024-06-14"}},
+		{"It broke a couple of weeks ago and will be fixed in a couple of days.",
+			[]string{"a couple of weeks ago/DATE/2024-05-29", "in a couple of days/DATE/2024-06-14"}},
+		{"It broke couple days ago.",
+			[]string{"couple days ago/DATE/2024-06-10"}},
+		{"We may need 12 more people in May to help.",
+			[]string{"May/DATE/2024-05"}},
+		{"It opened on Sept. 9 and closed on Jan. 5, 2024.",
+			[]string{"Sept. 9/DATE/2024-09-09", "Jan. 5, 2024/DATE/2024-01-05"}},
+		{"The report is due 9 Sept. 2024.",
+			[]string{"9 Sept. 2024/DATE/2024-09-09"}},
+	}
+
+	for _, test := range tests {
+		if observed := temporalStrings(test.text); !reflect.DeepEqual(observed, test.expected) {
+			t.Errorf("%q: got %v, expected %v", test.text, observed, test.expected)
+		}
+	}
+}
+
+func TestTemporalTimes(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"The call is at 3pm, or 3:30 p.m. at the latest.",
+			[]string{"3pm/TIME/2024-06-12T15:00", "3:30 p.m./TIME/2024-06-12T15:30"}},
+		{"Let's meet tomorrow at 15:45 or at noon on Friday.",
+			[]string{"tomorrow at 15:45/TIME/2024-06-13T15:45", "noon on Friday/TIME/2024-06-14T12:00"}},
+		{"It'll be ready in 20 minutes, which is 2 hours from now.",
+			[]string{"in 20 minutes/TIME/2024-06-12T10:01", "2 hours from now/TIME/2024-06-12T11:41"}},
+		{"He scored 3 goals at 12 and 13:75.",
+			[]string{}},
+	}
+
+	for _, test := range tests {
+		if observed := temporalStrings(test.text); !reflect.DeepEqual(observed, test.expected) {
+			t.Errorf("%q: got %v, expected %v", test.text, observed, test.expected)
+		}
+	}
+}
+
+func TestTemporalMerge(t *testing.T) {
+	doc := makeLabeled("Tuesday met Jane Smith on Friday",
+		"B-PERSON", "O", "B-PERSON", "I-PERSON", "O", "B-GPE")
+
+	checkError(TemporalComponent(temporalReference).Process(doc))
+
+	expected := []string{"Tuesday/DATE", "Jane Smith/PERSON", "Friday/DATE"}
+	if observed := entityStrings(doc.Entities()); !reflect.DeepEqual(observed, expected) {
+		t.Errorf("Entities() = %v, expected %v", observed, expected)
+	}
+
+	labels := []string{}
+	for _, tok := range doc.Tokens() {
+		labels = append(labels, tok.Label)
+	}
+	expected = []string{"B-DATE", "O", "B-PERSON", "I-PERSON", "O", "B-DATE"}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("labels = %v, expected %v", labels, expected)
+	}
+}
+
+func TestTemporalEmptyToken(t *testing.T) {
+	tokens := makeTagged("on|IN June|NNP 3|CD").tokens
+	tokens = append([]*Token{{}}, tokens...)
+
+	entities := findTemporal("on June 3", tokens, temporalReference)
+	if observed, expected := entityStrings(entities), []string{"June 3/DATE"}; !reflect.DeepEqual(observed, expected) {
+		t.Errorf("findTemporal() = %v, expected %v", observed, expected)
+	}
+}
@@ -1,6 +1,7 @@
 package prose
 
 import (
+	"context"
 	"regexp"
 	"strings"
 	"unicode"
@@ -158,48 +159,205 @@ func (t *iterTokenizer) doSplit(token string) []*Token {
 	return append(tokens, suffs...)
 }
 
-// tokenize splits a sentence into a slice of words.
+// Tokenize splits a sentence into a slice of words.
+//
+// Each token records its location within `text`. The sanitizer is applied to
+// one whitespace-delimited chunk at a time, which allows us to map offsets
+// in the sanitized text back onto the original (e.g., `&rsquo;` -> `'`).
 func (t *iterTokenizer) Tokenize(text string) []*Token {
+	tokens, _ := t.tokenizeContext(context.Background(), text)
+	return tokens
+}
+
+// tokenizeContext is Tokenize, but it stops early (returning the tokens found
+// so far) if `ctx` is done.
+func (t *iterTokenizer) tokenizeContext(ctx context.Context, text string) ([]*Token, error) {
 	var tokens []*Token
+	var err error
 
-	clean, white := t.sanitizer.Replace(text), false
-	length := len(clean)
+	runes := runeCounter{text: text}
+	cache := map[string][]Token{}
+	chunks := 0
+	forEachChunk(text, func(start, end int) bool {
+		if chunks%checkInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+		}
+		chunks++
+		chunk := text[start:end]
+		toks, found := cache[chunk]
+		if !found {
+			toks = t.splitChunk(chunk)
+			cache[chunk] = toks
+		}
+		for _, tok := range toks {
+			tok := tok
+			tok.Start += start
+			tok.End += start
+			tok.RuneStart = runes.at(tok.Start)
+			tok.RuneEnd = runes.at(tok.End)
+			tokens = append(tokens, &tok)
+		}
+		return true
+	})
 
-	start, index := 0, 0
-	cache := map[string][]*Token{}
-	for index <= length {
-		uc, size := utf8.DecodeRuneInString(clean[index:])
-		if size == 0 {
-			break
-		} else if index == 0 {
-			white = unicode.IsSpace(uc)
+	return tokens, err
+}
+
+// splitChunk tokenizes a single whitespace-delimited chunk of text, returning
+// tokens whose byte offsets are relative to `chunk`.
+func (t *iterTokenizer) splitChunk(chunk string) []Token {
+	var tokens []Token
+
+	clean := t.sanitizer.Replace(chunk)
+	forEachChunk(clean, func(start, end int) bool {
+		span, cursor := clean[start:end], 0
+		for _, tok := range t.doSplit(span) {
+			idx := strings.Index(span[cursor:], tok.Text)
+			if idx < 0 {
+				idx = 0
+			}
+			tok.Start = start + cursor + idx
+			tok.End = tok.Start + len(tok.Text)
+			cursor += idx + len(tok.Text)
+			tokens = append(tokens, *tok)
 		}
-		if unicode.IsSpace(uc) != white {
-			if start < index {
-				span := clean[start:index]
-				if toks, found := cache[span]; found {
-					tokens = append(tokens, toks...)
-				} else {
-					toks := t.doSplit(span)
-					cache[span] = toks
-					tokens = append(tokens, toks...)
-				}
+		return true
+	})
+
+	if clean != chunk {
+		offsets := t.offsetMap(chunk, clean)
+		for i := range tokens {
+			tokens[i].Start = offsets[tokens[i].Start]
+			tokens[i].End = offsets[tokens[i].End]
+		}
+	}
+
+	return tokens
+}
+
+// offsetMap maps each byte offset into `clean` (the sanitized form of `chunk`)
+// back onto `chunk`. An offset that falls within a replacement maps to the
+// replacement's start in `chunk`.
+//
+// Since the sanitizer is opaque, the chunk is recursively split at points
+// that no replacement spans (i.e., where sanitizing each half reproduces
+// `clean`), which keeps the work at O(n log n) for long chunks.
+func (t *iterTokenizer) offsetMap(chunk, clean string) []int {
+	offsets := make([]int, len(clean)+1)
+	t.alignSegment(chunk, clean, 0, 0, offsets)
+	offsets[len(clean)] = len(chunk)
+	return offsets
+}
+
+// alignSegmentLimit is the length below which alignSegment stops splitting.
+const alignSegmentLimit = 64
+
+// alignSegment fills in `offsets` for `clean`, the sanitized form of `raw`,
+// which begin at `cleanStart` and `rawStart` within their chunks.
+func (t *iterTokenizer) alignSegment(raw, clean string, rawStart, cleanStart int, offsets []int) {
+	if raw == clean {
+		for i := range clean {
+			offsets[cleanStart+i] = rawStart + i
+		}
+		return
+	}
+
+	if len(raw) > alignSegmentLimit {
+		mid := len(raw) / 2
+		for tries := 0; tries < 8 && mid < len(raw); tries++ {
+			for mid < len(raw) && !utf8.RuneStart(raw[mid]) {
+				mid++
 			}
-			if uc == ' ' {
-				start = index + 1
-			} else {
-				start = index
+			left := t.sanitizer.Replace(raw[:mid])
+			if strings.HasPrefix(clean, left) && t.sanitizer.Replace(raw[mid:]) == clean[len(left):] {
+				t.alignSegment(raw[:mid], left, rawStart, cleanStart, offsets)
+				t.alignSegment(raw[mid:], clean[len(left):], rawStart+mid, cleanStart+len(left), offsets)
+				return
 			}
-			white = !white
+			mid++
 		}
-		index += size
 	}
 
-	if start < index {
-		tokens = append(tokens, t.doSplit(clean[start:index])...)
+	// Each offset maps to the shortest prefix of `raw` that sanitizes to
+	// `clean`'s prefix of that length or, if there's none (because the offset
+	// falls within a replacement), to the shortest one that reaches past it.
+	exact, nearest := make([]int, len(clean)), make([]int, len(clean))
+	for c := range exact {
+		exact[c], nearest[c] = -1, len(raw)
 	}
 
-	return tokens
+	reached := 0
+	for i := 0; i <= len(raw); {
+		prefix := t.sanitizer.Replace(raw[:i])
+		if n := len(prefix); n < len(clean) && exact[n] < 0 && strings.HasPrefix(clean, prefix) {
+			exact[n] = i
+		}
+		for ; reached <= len(prefix) && reached < len(clean); reached++ {
+			nearest[reached] = i
+		}
+		if i == len(raw) {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(raw[i:])
+		i += size
+	}
+
+	for c := range clean {
+		if exact[c] >= 0 {
+			offsets[cleanStart+c] = rawStart + exact[c]
+		} else {
+			offsets[cleanStart+c] = rawStart + nearest[c]
+		}
+	}
+}
+
+// alignTokens assigns offsets to tokens produced by a Tokenizer that doesn't
+// report them itself by locating each token's text, in order, within `text`.
+//
+// Tokens that can't be found (e.g., because the Tokenizer rewrote them) are
+// given an empty span at the current position.
+func alignTokens(text string, tokens []*Token) {
+	for _, tok := range tokens {
+		if tok.End > 0 {
+			// The Tokenizer is offset-aware.
+			return
+		}
+	}
+
+	runes := runeCounter{text: text}
+	cursor := 0
+	for _, tok := range tokens {
+		tok.Start = cursor
+		if idx := strings.Index(text[cursor:], tok.Text); idx >= 0 {
+			tok.Start += idx
+			cursor = tok.Start + len(tok.Text)
+		}
+		tok.End = cursor
+		tok.RuneStart, tok.RuneEnd = runes.at(tok.Start), runes.at(tok.End)
+	}
+}
+
+// forEachChunk calls `fn` with the byte offsets of each maximal run of
+// non-whitespace characters in `text`, stopping early if `fn` returns false.
+func forEachChunk(text string, fn func(start, end int) bool) {
+	start := -1
+	for index, uc := range text {
+		if unicode.IsSpace(uc) {
+			if start >= 0 {
+				if !fn(start, index) {
+					return
+				}
+				start = -1
+			}
+		} else if start < 0 {
+			start = index
+		}
+	}
+	if start >= 0 {
+		fn(start, len(text))
+	}
 }
 
 var internalRE = regexp.MustCompile(`^(?:[A-Za-z]\.){2,}$|^[A-Z][a-z]{1,2}\.$`)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -208,6 +209,55 @@ func TestTokenizationContractions(t *testing.T) {
 	checkTokens(t, tokens, expected, "TokenizationContraction(custom-missing)")
 }
 
+func TestTokenizationOffsets(t *testing.T) {
+	text := "They\u2019ll say \u201cno\u201d, don&rsquo;t in caf\u00e9s :-) ."
+	doc, _ := makeDoc(text)
+
+	expected := []string{
+		"They", "\u2019ll", "say", "\u201c", "no", "\u201d", ",", "do",
+		"n&rsquo;t", "in", "caf\u00e9s", ":-)", "."}
+	tokens := doc.Tokens()
+	if len(tokens) != len(expected) {
+		t.Fatalf("TokenizationOffsets(): got = %d tokens, expected = %d",
+			len(tokens), len(expected))
+	}
+
+	runes := []rune(text)
+	for i, tok := range tokens {
+		if text[tok.Start:tok.End] != expected[i] {
+			t.Errorf("TokenizationOffsets(bytes): got = %q, expected = %q",
+				text[tok.Start:tok.End], expected[i])
+		}
+		if string(runes[tok.RuneStart:tok.RuneEnd]) != expected[i] {
+			t.Errorf("TokenizationOffsets(runes): got = %q, expected = %q",
+				string(runes[tok.RuneStart:tok.RuneEnd]), expected[i])
+		}
+	}
+}
+
+func TestTokenizationOffsetMap(t *testing.T) {
+	tokenizer := NewIterTokenizer()
+
+	// A long chunk without whitespace, full of replacements.
+	chunk := strings.Repeat("ab&rsquo;c\u2019", 5000)
+	clean := tokenizer.sanitizer.Replace(chunk)
+
+	offsets := tokenizer.offsetMap(chunk, clean)
+	if offsets[len(clean)] != len(chunk) {
+		t.Fatalf("offsetMap(end): got = %d, expected = %d", offsets[len(clean)], len(chunk))
+	}
+	for c := 0; c < len(clean); c++ {
+		raw := chunk[offsets[c]:]
+		if clean[c] == '\'' {
+			if !strings.HasPrefix(raw, "&rsquo;") && !strings.HasPrefix(raw, "\u2019") {
+				t.Fatalf("offsetMap(%d): got = %q, expected a replacement", c, raw[:8])
+			}
+		} else if raw[0] != clean[c] {
+			t.Fatalf("offsetMap(%d): got = %q, expected = %q", c, raw[0], clean[c])
+		}
+	}
+}
+
 func BenchmarkTokenization(b *testing.B) {
 	in := readDataFile(filepath.Join(testdata, "sherlock.txt"))
 	text := string(in)
@@ -1,20 +1,88 @@
 package prose
 
+// A Span represents the location of a piece of text within a Document's
+// original `Text`.
+//
+// Byte offsets index directly into the original string (`Text[Start:End]`),
+// while rune offsets count Unicode code points, which is what most editors
+// use to address a position.
+type Span struct {
+	Start     int // The byte offset of the first character.
+	End       int // The byte offset just past the last character.
+	RuneStart int // The rune offset of the first character.
+	RuneEnd   int // The rune offset just past the last character.
+}
+
+// shift moves `s` forward by the given number of bytes and runes.
+func (s *Span) shift(bytes, runes int) {
+	s.Start += bytes
+	s.End += bytes
+	s.RuneStart += runes
+	s.RuneEnd += runes
+}
+
 // A Token represents an individual token of text such as a word or punctuation
 // symbol.
 type Token struct {
+	Span
+
 	Tag   string // The token's part-of-speech tag.
 	Text  string // The token's actual content.
 	Label string // The token's IOB label.
+	Lemma string // The token's lemma (see WithLemmatization).
+	Stem  string // The token's stem (see WithStemming).
+
+	// Head is the index of the token's syntactic head within its sentence,
+	// or -1 for the sentence's root, and Dep is the label of the dependency
+	// between them (see WithParsing).
+	//
+	// Head is only meaningful if the token has been parsed (see Parsed),
+	// since its zero value is also the index of the sentence's first token.
+	Head int
+	Dep  string
+}
+
+// Parsed reports whether `t` has been assigned a Head and Dep by a
+// dependency parser.
+func (t Token) Parsed() bool {
+	return t.Dep != ""
 }
 
 // An Entity represents an individual named-entity.
 type Entity struct {
+	Span
+
 	Text  string // The entity's actual content.
 	Label string // The entity's label.
+
+	// Value is the entity's normalized value, if any (e.g., "2024-06-18" for
+	// the DATE "next Tuesday").
+	Value string
 }
 
 // A Sentence represents a segmented portion of text.
 type Sentence struct {
+	Span
+
 	Text string // The sentence's text.
+
+	entities []Entity
+	tokens   []*Token
+
+	classification Classification
+	sentiment      Sentiment
+}
+
+// Tokens returns the tokens that belong to `sent`.
+func (sent Sentence) Tokens() []Token {
+	tokens := make([]Token, 0, len(sent.tokens))
+	for _, tok := range sent.tokens {
+		tokens = append(tokens, *tok)
+	}
+	return tokens
+}
+
+// Entities returns the named-entities found within `sent`.
+func (sent Sentence) Entities() []Entity {
+	return sent.entities
 }
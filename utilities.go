@@ -4,11 +4,19 @@ import (
 	"bytes"
 	"encoding/gob"
 	"io/fs"
+	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// checkInterval is the number of units of work (e.g., tokens) between checks
+// for a cancelled context.Context.
+const checkInterval = 1024
+
 // checkError panics if `err` is not `nil`.
 func checkError(err error) {
 	if err != nil {
@@ -24,12 +32,42 @@ func min(a, b int) int {
 	return b
 }
 
+// runeCounter converts byte offsets into rune offsets for a given text.
+//
+// It's optimized for the common case of (mostly) increasing offsets, which
+// lets us avoid re-counting from the start of the text on every lookup.
+type runeCounter struct {
+	text   string
+	byteAt int
+	runeAt int
+}
+
+// at returns the rune offset corresponding to the byte offset `offset`.
+func (c *runeCounter) at(offset int) int {
+	if offset < c.byteAt {
+		c.byteAt, c.runeAt = 0, 0
+	}
+	c.runeAt += utf8.RuneCountInString(c.text[c.byteAt:offset])
+	c.byteAt = offset
+	return c.runeAt
+}
+
 // isPunct determines if the string represents a number.
 func isNumeric(s string) bool {
 	_, err := strconv.ParseFloat(s, 64)
 	return err == nil
 }
 
+// hasLetter determines if `s` contains at least one letter.
+func hasLetter(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
 // stringInSlice determines if `slice` contains the string `a`.
 func stringInSlice(a string, slice []string) bool {
 	for _, b := range slice {
@@ -40,14 +78,43 @@ func stringInSlice(a string, slice []string) bool {
 	return false
 }
 
-func getAsset(folder, name string) *gob.Decoder {
+// loadAsset decodes the built-in asset `folder/name` into `v`.
+func loadAsset(folder, name string, v interface{}) error {
 	b, err := Asset(path.Join("model", folder, name))
-	checkError(err)
-	return gob.NewDecoder(bytes.NewReader(b))
+	if err == nil {
+		err = gob.NewDecoder(bytes.NewReader(b)).Decode(v)
+	}
+	return assetError("load", path.Join(folder, name), err)
+}
+
+// loadDiskAsset decodes the asset `folder/name` from `filesys` into `v`.
+func loadDiskAsset(filesys fs.FS, folder, name string, v interface{}) error {
+	file, err := filesys.Open(path.Join(folder, name))
+	if err == nil {
+		defer file.Close()
+		err = gob.NewDecoder(file).Decode(v)
+	}
+	return assetError("load", path.Join(folder, name), err)
 }
 
-func getDiskAsset(file fs.File) *gob.Decoder {
-	return gob.NewDecoder(file)
+// saveDiskAsset encodes `v` into the file `folder/name` under `dir`.
+func saveDiskAsset(dir, folder, name string, v interface{}) error {
+	file, err := os.Create(filepath.Join(dir, folder, name))
+	if err == nil {
+		err = gob.NewEncoder(file).Encode(v)
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return assetError("save", path.Join(folder, name), err)
+}
+
+// assetError wraps a non-nil `err` in an AssetError.
+func assetError(op, asset string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &AssetError{Op: op, Asset: asset, Err: err}
 }
 
 func hasAnyPrefix(s string, prefixes []string) bool {